@@ -0,0 +1,110 @@
+package grape
+
+import "fmt"
+
+// As sets the external name clients submit the field under, e.g.
+// "firstName" for a field declared as "first_name", plus older names still
+// accepted for backward compatibility. Input and Input.ToModel keep the
+// declared name; errors, unknown-key checks and docs use the external one.
+// A name already used by another field panics.
+func (f *FieldBuilder) As(external string, aliases ...string) *FieldBuilder {
+	for _, key := range append([]string{external}, aliases...) {
+		f.parent.claim(f.param.Name, key)
+	}
+	f.param.External = external
+	f.param.Aliases = aliases
+	f.updateParent()
+	return f
+}
+
+// claim panics when key is a name a field of p other than the one declared
+// as name is submitted under. Fields of conditional blocks conflict even
+// when declared as name, since the key would be bound twice.
+func (p *Params) claim(name, key string) {
+	for _, other := range p.Fields {
+		if other.Name != name && containsString(other.keys(), key) {
+			panic(fmt.Sprintf("grape: name '%s' of field '%s' is already used by field '%s'", key, name, other.Name))
+		}
+	}
+	for _, c := range p.Conditions {
+		for _, other := range c.Schema.allFields() {
+			if other.Name == name {
+				panic(fmt.Sprintf("grape: field '%s' is already declared in a conditional block", name))
+			}
+			if containsString(other.keys(), key) {
+				panic(fmt.Sprintf("grape: name '%s' of field '%s' is already used by field '%s'", key, name, other.Name))
+			}
+		}
+	}
+}
+
+// claimBlock panics when a field of the conditional block sub is declared
+// by p or uses a name of one of p's fields.
+func (p *Params) claimBlock(sub *Params) {
+	for _, f := range sub.allFields() {
+		if p.declares(f.Name) {
+			panic(fmt.Sprintf("grape: field '%s' is already declared", f.Name))
+		}
+		for _, key := range f.keys() {
+			p.claim(f.Name, key)
+		}
+	}
+}
+
+// keys returns the names f is accepted under, the external name first.
+func (f Param) keys() []string {
+	if f.External == "" {
+		return append([]string{f.Name}, f.Aliases...)
+	}
+	return append([]string{f.External}, f.Aliases...)
+}
+
+// lookup returns the value submitted for f under the first of its names
+// present in raw.
+func (f Param) lookup(raw map[string]interface{}) (interface{}, bool) {
+	for _, key := range f.keys() {
+		if v, ok := raw[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// external returns f named as clients see it, for lookups and errors.
+func (f Param) external() Param {
+	if f.External != "" {
+		f.Name = f.External
+	}
+	return f
+}
+
+// field returns the field declared as name, including fields of
+// conditional blocks.
+func (p *Params) field(name string) (Param, bool) {
+	for _, f := range p.allFields() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Param{}, false
+}
+
+// accepts reports whether key is a name one of p's fields is submitted
+// under, including fields of conditional blocks.
+func (p *Params) accepts(key string) bool {
+	for _, f := range p.allFields() {
+		if containsString(f.keys(), key) {
+			return true
+		}
+	}
+	return false
+}
+
+// allFields returns the fields of p and of its conditional blocks.
+func (p *Params) allFields() []Param {
+	fields := append([]Param(nil), p.Fields...)
+	for _, c := range p.Conditions {
+		fields = append(fields, c.Schema.allFields()...)
+	}
+	return fields
+}
@@ -0,0 +1,179 @@
+// Package grape provides tests for alias.go functionality.
+//
+// Test Functions:
+// - TestAsExternalName: Tests binding an external name into the declared key
+// - TestAsAliases: Tests accepting older names for backward compatibility
+// - TestAsErrorsUseExternalName: Tests that errors report the external name
+// - TestAsUnknownKeys: Tests that only external names and aliases are accepted
+// - TestAsGroupsAndQuery: Tests group rules and query binding with external names
+// - TestAsConflict: Tests the definition-time name conflict check
+package grape
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestAsExternalName(t *testing.T) {
+	type user struct {
+		FirstName string
+		Email     string
+	}
+	schema := NewParams()
+	_ = schema.Requires("first_name").On("create").String().As("firstName")
+	_ = schema.Optional("email").String().As("e-mail")
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"firstName": "Ada", "e-mail": "ada@example.com"}`), "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("first_name") != "Ada" || input.String("email") != "ada@example.com" {
+		t.Errorf("Expected declared keys, got %v", input)
+	}
+	if _, ok := input["firstName"]; ok {
+		t.Error("Expected external name not to be copied into Input")
+	}
+
+	var u user
+	input.ToModel(&u)
+	if u.FirstName != "Ada" || u.Email != "ada@example.com" {
+		t.Errorf("Expected model fields to be set, got %+v", u)
+	}
+}
+
+func TestAsAliases(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("first_name").String().As("firstName", "first_name", "fname")
+
+	for _, body := range []string{`{"firstName": "A"}`, `{"first_name": "A"}`, `{"fname": "A"}`} {
+		input, err := schema.BindAndValidate(createTestJSON(body), "")
+		if err != nil || input.String("first_name") != "A" {
+			t.Errorf("%s: expected first_name A, got %v, %v", body, input, err)
+		}
+	}
+
+	// The external name wins over aliases
+	input, _ := schema.BindAndValidate(createTestJSON(`{"fname": "old", "firstName": "new"}`), "")
+	if input.String("first_name") != "new" {
+		t.Errorf("Expected external name to win, got %v", input["first_name"])
+	}
+	if schema.Fields[0].External != "firstName" || len(schema.Fields[0].Aliases) != 2 {
+		t.Errorf("Expected introspectable names, got %+v", schema.Fields[0])
+	}
+}
+
+func TestAsErrorsUseExternalName(t *testing.T) {
+	address := NewParams()
+	_ = address.Requires("zip_code").On("create").String().As("zipCode")
+	schema := NewParams()
+	_ = schema.Requires("first_name").On("create").String().As("firstName")
+	_ = schema.Optional("age").Integer().As("userAge").Validate("min=18")
+	_ = schema.Optional("address").JSON().WithSchema(address)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"userAge": 3, "address": {}}`), "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	expected := []struct{ field, path, msg string }{
+		{"firstName", "/firstName", "missing required field 'firstName' for create"},
+		{"userAge", "/userAge", ""},
+		{"zipCode", "/address/zipCode", "missing required field 'zipCode' for create"},
+	}
+	for i, e := range expected {
+		if verrs[i].Field != e.field || verrs[i].Path != e.path || (e.msg != "" && verrs[i].Message != e.msg) {
+			t.Errorf("Error %d: expected %s at %s, got %+v", i, e.field, e.path, verrs[i])
+		}
+	}
+}
+
+func TestAsUnknownKeys(t *testing.T) {
+	schema := NewParams().UnknownKeys(RejectUnknown)
+	_ = schema.Optional("first_name").String().As("firstName", "fname")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"fname": "A"}`), ""); err != nil {
+		t.Errorf("Expected alias to be accepted, got %v", err)
+	}
+	_, err := schema.BindAndValidate(createTestJSON(`{"first_name": "A"}`), "")
+	if err == nil || err.Error() != "unknown field 'first_name'" {
+		t.Errorf("Expected declared name to be unknown to clients, got %v", err)
+	}
+}
+
+func TestAsGroupsAndQuery(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("user_id").Integer().As("userId")
+	_ = schema.Optional("email").String()
+	schema.ExactlyOneOf("user_id", "email")
+
+	input, err := schema.BindQuery(url.Values{"userId": {"42"}}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("user_id", 0) != 42 {
+		t.Errorf("Expected coerced user_id, got %v", input["user_id"])
+	}
+
+	_, err = schema.BindQuery(url.Values{"userId": {"42"}, "email": {"a@b.c"}}, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || verrs[0].Field != "userId,email" || verrs[0].Message != "exactly one of 'userId', 'email' must be provided" {
+		t.Errorf("Expected group error with external names, got %v", err)
+	}
+}
+
+func TestAsConflict(t *testing.T) {
+	expectDefinitionPanic(t, "external name", func() {
+		schema := NewParams()
+		_ = schema.Optional("email").String()
+		_ = schema.Optional("contact").String().As("email")
+	})
+	expectDefinitionPanic(t, "alias", func() {
+		schema := NewParams()
+		_ = schema.Optional("name").String().As("fullName")
+		_ = schema.Optional("nick").String().As("nickName", "fullName")
+	})
+	expectDefinitionPanic(t, "merge", func() {
+		other := NewParams()
+		_ = other.Optional("contact_email").String().As("email")
+		schema := NewParams()
+		_ = schema.Optional("email").String()
+		schema.Merge(other)
+	})
+	expectDefinitionPanic(t, "field declared after", func() {
+		schema := NewParams()
+		_ = schema.Optional("a").String().As("x")
+		_ = schema.Optional("x").String()
+	})
+	expectDefinitionPanic(t, "given block", func() {
+		schema := NewParams()
+		_ = schema.Optional("kind").String()
+		_ = schema.Optional("email").String()
+		schema.Given("kind", nil, func(p *Params) {
+			_ = p.Optional("contact").String().As("email")
+		})
+	})
+	expectDefinitionPanic(t, "same name in given block", func() {
+		schema := NewParams()
+		_ = schema.Optional("kind").String()
+		_ = schema.Optional("amount").Integer()
+		schema.Given("kind", nil, func(p *Params) {
+			_ = p.Optional("amount").String()
+		})
+	})
+	expectDefinitionPanic(t, "same name after given block", func() {
+		schema := NewParams()
+		_ = schema.Optional("kind").String()
+		schema.Given("kind", nil, func(p *Params) {
+			_ = p.Optional("amount").String()
+		})
+		_ = schema.Optional("amount").Integer()
+	})
+	expectDefinitionPanic(t, "field declared after given", func() {
+		schema := NewParams()
+		_ = schema.Optional("kind").String()
+		schema.Given("kind", nil, func(p *Params) {
+			_ = p.Optional("contact").String().As("email")
+		})
+		_ = schema.Optional("email").String()
+	})
+}
@@ -0,0 +1,158 @@
+package grape
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// errNoStructField is the Err of ModelErrors for schema fields the struct
+// has no field for.
+var errNoStructField = errors.New("no matching struct field")
+
+// Bind binds raw against p for mode, like BindAndValidate, and maps the
+// result into a new T with Input.ToModelE, so handlers get a typed value:
+//
+//	req, err := grape.Bind[CreateUser](userSchema, raw, "create")
+//
+// T must be a struct type with a field for every field of p, including
+// those of nested schemas; a nil p uses ParamsFromStruct for T. Only the
+// fields p declares are mapped: keys passed through as unknown never
+// reach T, whatever the unknown-key policy. Invalid
+// input is reported as ValidationErrors. A schema and struct that
+// disagree, by a missing field or a value its field can't hold, are
+// reported as a *ModelError naming the field.
+func Bind[T any](p *Params, raw map[string]interface{}, mode string) (T, error) {
+	var zero T
+	if p == nil {
+		p = ParamsFromStruct(zero)
+	}
+	input, err := p.BindAndValidate(raw, mode)
+	if err != nil {
+		return zero, err
+	}
+	return bindModel[T](p, input)
+}
+
+// BindReader is Bind for a JSON body, binding like BindAndValidateReader.
+func BindReader[T any](p *Params, reader io.Reader, mode string) (T, error) {
+	var zero T
+	if p == nil {
+		p = ParamsFromStruct(zero)
+	}
+	input, err := p.BindAndValidateReader(reader, mode)
+	if err != nil {
+		return zero, err
+	}
+	return bindModel[T](p, input)
+}
+
+// bindModel maps input, bound by p, into a new T.
+func bindModel[T any](p *Params, input Input) (T, error) {
+	var v, zero T
+	if err := Input(p.declaredOnly(input)).ToModelE(&v); err != nil {
+		return zero, err
+	}
+	if err := checkModel(p, reflect.TypeOf(v), "", map[modelCheck]bool{}); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// declaredOnly returns the values of m for the fields p declares, with
+// the objects of nested schemas filtered likewise.
+func (p *Params) declaredOnly(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for _, f := range p.allFields() {
+		v, ok := m[f.Name]
+		if !ok {
+			continue
+		}
+		if f.Schema != nil {
+			v = f.Schema.declaredValue(v, f.Type == Map)
+		}
+		out[f.Name] = v
+	}
+	return out
+}
+
+// declaredValue filters the objects of p in v, a value of a field with
+// schema p: an object, an array of them or, for maps, an object of them.
+func (p *Params) declaredValue(v interface{}, isMap bool) interface{} {
+	switch vv := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(vv))
+		for i, e := range vv {
+			out[i] = p.declaredValue(e, false)
+		}
+		return out
+	case map[string]interface{}:
+		if !isMap {
+			return p.declaredOnly(vv)
+		}
+		out := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			out[k] = p.declaredValue(e, false)
+		}
+		return out
+	}
+	return v
+}
+
+// modelCheck is a schema and the struct type checkModel checked it against.
+type modelCheck struct {
+	p *Params
+	t reflect.Type
+}
+
+// checkModel reports the first field of p, or of its nested schemas, that
+// the struct type t has no field for. checked holds the pairs already
+// checked, so recursive schemas and types are checked once.
+func checkModel(p *Params, t reflect.Type, path string, checked map[modelCheck]bool) error {
+	if checked[modelCheck{p, t}] {
+		return nil
+	}
+	checked[modelCheck{p, t}] = true
+	fields := modelFields(t)
+	for _, f := range p.allFields() {
+		sf, ok := fields[f.Name]
+		if !ok {
+			return &ModelError{Path: pointer(path, f.Name), Type: t, Err: errNoStructField}
+		}
+		if f.Schema == nil {
+			continue
+		}
+		et := derefType(sf.Type)
+		if et.Kind() == reflect.Slice || et.Kind() == reflect.Map {
+			et = derefType(et.Elem())
+		}
+		if et.Kind() == reflect.Struct {
+			if err := checkModel(f.Schema, et, pointer(path, f.Name), checked); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// modelFields returns the fields of the struct type t by the Input key
+// ToModelE maps into them.
+func modelFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		if et, ok := embeddedStruct(sf); ok {
+			for k, f := range modelFields(et) {
+				fields[k] = f
+			}
+			continue
+		}
+		if key, ok := modelKey(sf); ok && sf.IsExported() {
+			fields[key] = sf
+		}
+	}
+	return fields
+}
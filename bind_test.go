@@ -0,0 +1,203 @@
+// Package grape provides tests for bind.go functionality.
+//
+// Test Functions:
+// - TestBind: Tests binding into a typed struct with a hand-written schema
+// - TestBindDerivedSchema: Tests binding with a nil schema derived from the struct
+// - TestBindReader: Tests the reader variant with exact numbers
+// - TestBindValidationErrors: Tests that invalid input returns ValidationErrors
+// - TestBindSchemaMismatch: Tests errors for schema fields the struct lacks or can't hold
+// - TestBindEmbeddedPointer: Tests flattening embedded struct pointers
+// - TestBindRecursive: Tests binding a recursive type
+// - TestBindUndeclaredKeys: Tests that undeclared keys never reach the struct
+package grape
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBind(t *testing.T) {
+	address := NewParams()
+	_ = address.Requires("city").On("create").String()
+
+	schema := NewParams()
+	_ = schema.Requires("first_name").On("create").String().As("firstName")
+	_ = schema.Optional("age").Integer()
+	_ = schema.Optional("address").JSON().WithSchema(address)
+
+	type Address struct {
+		City string
+	}
+	type User struct {
+		FirstName string
+		Age       *int
+		Address   Address
+	}
+	user, err := Bind[User](schema, createTestJSON(`{"firstName": "John", "age": 30, "address": {"city": "Oslo"}}`), "create")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.FirstName != "John" || user.Age == nil || *user.Age != 30 || user.Address.City != "Oslo" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+}
+
+func TestBindDerivedSchema(t *testing.T) {
+	type Signup struct {
+		Email string    `grape:"email,required,validate=email"`
+		Plan  string    `grape:"plan,default=free"`
+		Born  time.Time `grape:"born,type=date"`
+	}
+	s, err := Bind[Signup](nil, createTestJSON(`{"email": "a@b.c", "born": "1990-05-01"}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Email != "a@b.c" || s.Plan != "free" || s.Born.Year() != 1990 {
+		t.Errorf("Unexpected signup %+v", s)
+	}
+}
+
+func TestBindReader(t *testing.T) {
+	type Payment struct {
+		ID     int64   `grape:"id,required"`
+		Amount Decimal `grape:"amount,required"`
+	}
+	p, err := BindReader[Payment](nil, strings.NewReader(`{"id": 9007199254740993, "amount": 0.10}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.ID != 9007199254740993 || p.Amount.String() != "0.10" {
+		t.Errorf("Unexpected payment %+v", p)
+	}
+
+	if _, err := BindReader[Payment](nil, strings.NewReader(`{`), ""); err == nil {
+		t.Error("Expected a decode error")
+	}
+}
+
+func TestBindValidationErrors(t *testing.T) {
+	type Signup struct {
+		Email string `grape:"email,required,validate=email"`
+	}
+	s, err := Bind[Signup](nil, createTestJSON(`{"email": "nope"}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Field != "email" {
+		t.Errorf("Expected validation error for email, got %v", err)
+	}
+	if s != (Signup{}) {
+		t.Errorf("Expected zero value, got %+v", s)
+	}
+}
+
+func TestBindSchemaMismatch(t *testing.T) {
+	item := NewParams()
+	_ = item.Optional("sku").String()
+	_ = item.Optional("qty").Integer()
+
+	schema := NewParams()
+	_ = schema.Optional("name").String()
+	_ = schema.Optional("items").SliceOf(JSON, item)
+
+	type Item struct {
+		SKU string
+	}
+	type Order struct {
+		Name  int
+		Items []Item
+	}
+	type Named struct {
+		Name string
+	}
+	type NamedItems struct {
+		Name  string
+		Items []Item
+	}
+
+	tests := []struct {
+		name  string
+		bind  func() error
+		path  string
+		field string
+	}{
+		{"wrong type", func() error { _, err := Bind[Order](schema, createTestJSON(`{"name": "x"}`), ""); return err }, "/name", "Name"},
+		{"missing field", func() error { _, err := Bind[Named](schema, createTestJSON(`{"name": "x"}`), ""); return err }, "/items", ""},
+		{"missing nested field", func() error { _, err := Bind[NamedItems](schema, createTestJSON(`{}`), ""); return err }, "/items/qty", ""},
+	}
+	for _, tt := range tests {
+		var merr *ModelError
+		if err := tt.bind(); !errors.As(err, &merr) {
+			t.Errorf("%s: expected ModelError, got %v", tt.name, err)
+			continue
+		}
+		if merr.Path != tt.path || merr.Field != tt.field {
+			t.Errorf("%s: expected %s %q, got %s %q", tt.name, tt.path, tt.field, merr.Path, merr.Field)
+		}
+	}
+}
+
+func TestBindEmbeddedPointer(t *testing.T) {
+	type Base struct {
+		ID int `grape:"id,required"`
+	}
+	type Post struct {
+		*Base
+		Title string
+	}
+	p, err := Bind[Post](nil, createTestJSON(`{"id": 7, "title": "Hi"}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Base == nil || p.ID != 7 || p.Title != "Hi" {
+		t.Errorf("Unexpected post %+v", p)
+	}
+
+	// The pointer stays nil when none of its fields are given
+	var post Post
+	if err := (Input{"title": "Hi"}).ToModelE(&post); err != nil || post.Base != nil {
+		t.Errorf("Expected a nil Base, got %+v, %v", post, err)
+	}
+}
+
+func TestBindRecursive(t *testing.T) {
+	type Node struct {
+		Name     string
+		Children []Node
+	}
+	n, err := Bind[Node](nil, createTestJSON(`{"name": "a", "children": [{"name": "b"}]}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.Name != "a" || len(n.Children) != 1 || n.Children[0].Name != "b" {
+		t.Errorf("Unexpected node %+v", n)
+	}
+	if _, err := Bind[Node](nil, createTestJSON(`{"name": "a"}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestBindUndeclaredKeys(t *testing.T) {
+	profile := NewParams().UnknownKeys(PassthroughUnknown)
+	_ = profile.Optional("bio").String()
+	schema := NewParams()
+	_ = schema.Optional("name").String()
+	_ = schema.Optional("profiles").SliceOf(JSON, profile)
+
+	type Profile struct {
+		Bio      string
+		Verified bool
+	}
+	type User struct {
+		Name     string
+		IsAdmin  bool
+		Profiles []Profile
+	}
+	u, err := Bind[User](schema, createTestJSON(`{"name": "x", "is_admin": true, "profiles": [{"bio": "b", "verified": true}]}`), "create")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if u.Name != "x" || u.IsAdmin || len(u.Profiles) != 1 || u.Profiles[0].Bio != "b" || u.Profiles[0].Verified {
+		t.Errorf("Expected only declared fields to be mapped, got %+v", u)
+	}
+}
@@ -0,0 +1,41 @@
+package grape
+
+import "encoding/json"
+
+// CoerceWith sets a function converting submitted values before the type
+// check and validation, e.g. splitting "1,2,3" into a slice or turning an
+// epoch number into a time.Time. Numbers arrive as float64, except for
+// BigDecimal and integer fields, which receive json.Number from
+// BindAndValidateReader. BindQuery and BindForm pass the submitted strings
+// as is: a string, or a []interface{} of strings for repeated keys.
+// Defaults are not coerced. An error is reported as coercion_failed.
+func (f *FieldBuilder) CoerceWith(fn func(interface{}) (interface{}, error)) *FieldBuilder {
+	f.param.Coerce = fn
+	f.updateParent()
+	return f
+}
+
+// decoded converts a json.Number from BindAndValidateReader to float64
+// unless f needs its exact text.
+func (f Param) decoded(val interface{}) interface{} {
+	if n, ok := val.(json.Number); ok && f.Type != BigDecimal && !isInteger(f.Type) {
+		if fv, err := n.Float64(); err == nil {
+			return fv
+		}
+	}
+	return val
+}
+
+// coerce applies f's coercion function to a submitted value. Failures are
+// recorded in errs at path.
+func (ve *ValidationErrors) coerce(f Param, path string, val interface{}) (interface{}, bool) {
+	if f.Coerce == nil {
+		return val, true
+	}
+	v, err := f.Coerce(f.decoded(val))
+	if err != nil {
+		ve.add(CodeCoercionFailed, f.Name, path, "coerce", val, MsgCoercionFailed, f.Name, err.Error())
+		return nil, false
+	}
+	return v, true
+}
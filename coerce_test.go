@@ -0,0 +1,159 @@
+// Package grape provides tests for coerce.go functionality.
+//
+// Test Functions:
+// - TestCoerceWithCommaSeparated: Tests splitting a string into a slice before the type check
+// - TestCoerceWithRunsBeforeValidation: Tests that the converted value is validated
+// - TestCoerceWithError: Tests coercion_failed errors
+// - TestCoerceWithNested: Tests coercion in nested schemas
+// - TestCoerceWithQuery: Tests that query strings reach the coercion function unchanged
+// - TestCoerceWithSkipsDefaults: Tests that defaults are not coerced
+package grape
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func splitCSV(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]interface{}, len(parts))
+	for i, p := range parts {
+		out[i] = strings.TrimSpace(p)
+	}
+	return out, nil
+}
+
+func yesNo(v interface{}) (interface{}, error) {
+	switch v {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return v, nil
+}
+
+func TestCoerceWithCommaSeparated(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("tags").SliceOf(String, nil).CoerceWith(splitCSV)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"tags": "a, b,c"}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tags := input["tags"].([]interface{})
+	if len(tags) != 3 || tags[1] != "b" {
+		t.Errorf("Expected 3 tags, got %v", tags)
+	}
+}
+
+func TestCoerceWithRunsBeforeValidation(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("subscribe").Boolean().CoerceWith(yesNo)
+	_ = schema.Optional("at").DateTime().CoerceWith(func(v interface{}) (interface{}, error) {
+		if f, ok := v.(float64); ok {
+			return time.Unix(int64(f), 0), nil
+		}
+		return v, nil
+	})
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"subscribe": "yes", "at": 86400}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !input.Boolean("subscribe", false) {
+		t.Error("Expected subscribe to be true")
+	}
+	if !input.DateValue("at").Equal(time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected epoch time, got %v", input["at"])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"subscribe": "maybe"}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != CodeTypeMismatch {
+		t.Errorf("Expected type mismatch after coercion, got %v", err)
+	}
+}
+
+func TestCoerceWithError(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("ids").SliceOf(Integer, nil).CoerceWith(func(v interface{}) (interface{}, error) {
+		return nil, errors.New("bad list")
+	})
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"ids": "1;2"}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 {
+		t.Fatalf("Expected 1 error, got %v", err)
+	}
+	e := verrs[0]
+	if e.Code != CodeCoercionFailed || e.Rule != "coerce" || e.Value != "1;2" {
+		t.Errorf("Unexpected error %+v", e)
+	}
+	if e.Message != "field 'ids' could not be converted: bad list" {
+		t.Errorf("Unexpected message %q", e.Message)
+	}
+}
+
+func TestCoerceWithNested(t *testing.T) {
+	prefs := NewParams()
+	_ = prefs.Optional("newsletter").String().CoerceWith(func(v interface{}) (interface{}, error) {
+		if b, ok := v.(bool); ok && b {
+			return "weekly", nil
+		}
+		return nil, errors.New("unsupported")
+	})
+	schema := NewParams()
+	_ = schema.Optional("prefs").JSON().WithSchema(prefs)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"prefs": {"newsletter": true}}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input["prefs"].(map[string]interface{})["newsletter"] != "weekly" {
+		t.Errorf("Expected coerced nested value, got %v", input["prefs"])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"prefs": {"newsletter": false}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/prefs/newsletter" || verrs[0].Code != CodeCoercionFailed {
+		t.Errorf("Expected nested coercion error, got %v", err)
+	}
+}
+
+func TestCoerceWithQuery(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("ids").SliceOf(String, nil).CoerceWith(splitCSV)
+
+	input, err := schema.BindQuery(url.Values{"ids": {"7,8"}}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ids := input["ids"].([]interface{}); len(ids) != 2 || ids[0] != "7" {
+		t.Errorf("Expected split ids, got %v", input["ids"])
+	}
+}
+
+func TestCoerceWithSkipsDefaults(t *testing.T) {
+	calls := 0
+	schema := NewParams()
+	_ = schema.Optional("subscribe").Boolean().Default(false).CoerceWith(func(v interface{}) (interface{}, error) {
+		calls++
+		return yesNo(v)
+	})
+
+	input, err := schema.BindAndValidate(createTestJSON(`{}`), "")
+	if err != nil || input.Boolean("subscribe", true) {
+		t.Errorf("Expected default false, got %v, %v", input, err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected no coercion of defaults, got %d calls", calls)
+	}
+}
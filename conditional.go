@@ -0,0 +1,99 @@
+package grape
+
+import "fmt"
+
+// Condition is a block of fields that only applies when Predicate holds on
+// the bound value of Field.
+type Condition struct {
+	Field     string
+	Predicate func(value interface{}) bool // nil holds whenever Field is bound
+	Values    []interface{}                // values of an Equals predicate; nil for other predicates
+	Schema    *Params
+}
+
+// Given declares fields that are bound and enforced only when predicate
+// holds on the bound value of field. When field is absent or fails to
+// bind, or the predicate is false, the block's fields are dropped from the
+// input. Blocks may be nested:
+//
+//	schema.Given("payment_method", grape.Equals("card"), func(p *grape.Params) {
+//		_ = p.Requires("card_number").On("create").String().Validate("credit_card")
+//	})
+func (p *Params) Given(field string, predicate func(value interface{}) bool, block func(*Params)) *Params {
+	if block == nil {
+		panic(fmt.Sprintf("grape: Given('%s') needs a block", field))
+	}
+	sub := NewParams()
+	block(sub)
+	p.claimBlock(sub)
+	values, _ := equalsValues(predicate)
+	p.Conditions = append(p.Conditions, Condition{Field: field, Predicate: predicate, Values: values, Schema: sub})
+	return p
+}
+
+// Equals returns a predicate for Given that holds when the value equals
+// one of values. Numbers compare like Values. Given records the values in
+// the Condition so documentation can describe it.
+func Equals(values ...interface{}) func(value interface{}) bool {
+	return func(value interface{}) bool {
+		if probe, ok := value.(*equalsProbe); ok {
+			probe.values = values
+			probe.ok = true
+			return true
+		}
+		for _, v := range values {
+			if sameValue(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// equalsProbe is passed to a predicate to ask an Equals predicate for its
+// values; no bound value has its type.
+type equalsProbe struct {
+	values []interface{}
+	ok     bool
+}
+
+// equalsValues returns the values of a predicate made by Equals, reporting
+// false for other predicates.
+func equalsValues(predicate func(value interface{}) bool) (values []interface{}, ok bool) {
+	if predicate == nil {
+		return nil, false
+	}
+	// Other predicates may not expect the probe
+	defer func() {
+		if recover() != nil {
+			values, ok = nil, false
+		}
+	}()
+	probe := &equalsProbe{}
+	predicate(probe)
+	if !probe.ok {
+		return nil, false
+	}
+	return append([]interface{}{}, probe.values...), true
+}
+
+// holds reports whether c applies to the fields bound so far.
+func (c Condition) holds(out map[string]interface{}) bool {
+	v, ok := out[c.Field]
+	if !ok {
+		return false
+	}
+	return c.Predicate == nil || c.Predicate(v)
+}
+
+// activeBlocks returns the schemas of p's conditions that hold on out,
+// each inheriting p's unknown-key policy for its nested schemas.
+func (p *Params) activeBlocks(out map[string]interface{}) []*Params {
+	var blocks []*Params
+	for _, c := range p.Conditions {
+		if c.holds(out) {
+			blocks = append(blocks, c.Schema.within(p))
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,146 @@
+// Package grape provides tests for conditional.go functionality.
+//
+// Test Functions:
+// - TestGivenRequiresWhenPredicateHolds: Tests that block fields are enforced only when the predicate holds
+// - TestGivenDropsFieldsWhenPredicateFails: Tests that block fields are dropped otherwise
+// - TestGivenNilPredicate: Tests blocks that apply whenever the field is bound
+// - TestGivenNested: Tests nested blocks and blocks inside nested schemas
+// - TestGivenUnknownKeys: Tests that block fields count as declared
+// - TestGivenIntrospection: Tests that conditions are readable from Params
+package grape
+
+import (
+	"testing"
+)
+
+func paymentSchema() *Params {
+	schema := NewParams()
+	_ = schema.Requires("payment_method").On("create").String().Values("card", "invoice")
+	schema.Given("payment_method", Equals("card"), func(p *Params) {
+		_ = p.Requires("card_number").On("create").String().Validate("len=16")
+	})
+	return schema
+}
+
+func TestGivenRequiresWhenPredicateHolds(t *testing.T) {
+	_, err := paymentSchema().BindAndValidate(createTestJSON(`{"payment_method": "card"}`), "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != CodeRequired || verrs[0].Field != "card_number" {
+		t.Fatalf("Expected card_number to be required, got %v", err)
+	}
+
+	_, err = paymentSchema().BindAndValidate(createTestJSON(`{"payment_method": "card", "card_number": "123"}`), "create")
+	if err == nil || err.(ValidationErrors)[0].Code != "validation_failed:len" {
+		t.Errorf("Expected block validator to run, got %v", err)
+	}
+
+	input, err := paymentSchema().BindAndValidate(createTestJSON(`{"payment_method": "card", "card_number": "4111111111111111"}`), "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("card_number") != "4111111111111111" {
+		t.Errorf("Expected card_number, got %v", input["card_number"])
+	}
+}
+
+func TestGivenDropsFieldsWhenPredicateFails(t *testing.T) {
+	input, err := paymentSchema().BindAndValidate(createTestJSON(`{"payment_method": "invoice", "card_number": 7}`), "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := input["card_number"]; ok {
+		t.Errorf("Expected card_number to be dropped, got %v", input["card_number"])
+	}
+
+	// An invalid controlling field does not activate the block
+	_, err = paymentSchema().BindAndValidate(createTestJSON(`{"payment_method": "cash"}`), "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Field != "payment_method" {
+		t.Errorf("Expected only the payment_method error, got %v", err)
+	}
+}
+
+func TestGivenNilPredicate(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("coupon").String()
+	schema.Given("coupon", nil, func(p *Params) {
+		_ = p.Requires("campaign").On("").String()
+	})
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{}`), ""); err != nil {
+		t.Errorf("Expected no error without coupon, got %v", err)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"coupon": "X"}`), ""); err == nil {
+		t.Error("Expected campaign to be required with coupon")
+	}
+}
+
+func TestGivenNested(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("shipping").String()
+	schema.Given("shipping", Equals("delivery"), func(p *Params) {
+		_ = p.Optional("express").Boolean()
+		p.Given("express", Equals(true), func(p *Params) {
+			_ = p.Requires("slot").On("").Integer().Validate("min=1")
+		})
+	})
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"shipping": "delivery", "express": true}`), "")
+	if err == nil || err.Error() != "missing required field 'slot' for " {
+		t.Errorf("Expected nested block to apply, got %v", err)
+	}
+	input, err := schema.BindAndValidate(createTestJSON(`{"shipping": "pickup", "express": true, "slot": 0}`), "")
+	if err != nil || len(input) != 1 {
+		t.Errorf("Expected only shipping, got %v, %v", input, err)
+	}
+
+	order := NewParams()
+	_ = order.Optional("delivery").JSON().WithSchema(schema)
+	_, err = order.BindAndValidate(createTestJSON(`{"delivery": {"shipping": "delivery", "express": true}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/delivery/slot" {
+		t.Errorf("Expected error at /delivery/slot, got %v", err)
+	}
+}
+
+func TestGivenUnknownKeys(t *testing.T) {
+	schema := paymentSchema().UnknownKeys(RejectUnknown)
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"payment_method": "invoice", "card_number": "x"}`), "create"); err != nil {
+		t.Errorf("Expected block field not to be unknown, got %v", err)
+	}
+	_, err := schema.BindAndValidate(createTestJSON(`{"payment_method": "invoice", "iban": "x"}`), "create")
+	if err == nil || err.(ValidationErrors)[0].Code != CodeUnknownField {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+}
+
+func TestGivenIntrospection(t *testing.T) {
+	schema := paymentSchema()
+	if len(schema.Conditions) != 1 {
+		t.Fatalf("Expected 1 condition, got %d", len(schema.Conditions))
+	}
+	c := schema.Conditions[0]
+	if c.Field != "payment_method" || !c.Predicate("card") || c.Predicate("invoice") {
+		t.Errorf("Unexpected condition %+v", c)
+	}
+	if len(c.Values) != 1 || c.Values[0] != "card" {
+		t.Errorf("Expected the Equals values, got %v", c.Values)
+	}
+	other := NewParams()
+	_ = other.Optional("n").Integer()
+	other.Given("n", func(v interface{}) bool { return v.(int) > 1 }, func(p *Params) {})
+	if other.Conditions[0].Values != nil {
+		t.Errorf("Expected no values for a custom predicate, got %v", other.Conditions[0].Values)
+	}
+	if len(c.Schema.Fields) != 1 || c.Schema.Fields[0].Name != "card_number" {
+		t.Errorf("Expected block fields, got %+v", c.Schema.Fields)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for nil block")
+		}
+	}()
+	NewParams().Given("a", nil, nil)
+}
@@ -0,0 +1,115 @@
+package grape
+
+import (
+	"strings"
+	"time"
+)
+
+// Default layouts for Date, DateTime and Time fields.
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = time.RFC3339
+	TimeLayout     = "15:04:05"
+)
+
+// Layouts sets the layouts accepted by a Date, DateTime or Time field, tried
+// in order. Without it the field uses DateLayout, DateTimeLayout or
+// TimeLayout.
+func (f *FieldBuilder) Layouts(layouts ...string) *FieldBuilder {
+	f.param.Layouts = layouts
+	f.updateParent()
+	return f
+}
+
+// Location sets the zone bound Date, DateTime and Time values are
+// converted to. Values without zone information are read in loc. The
+// default is UTC.
+func (f *FieldBuilder) Location(loc *time.Location) *FieldBuilder {
+	f.param.Location = loc
+	f.updateParent()
+	return f
+}
+
+// DateValue returns a bound Date or DateTime field as time.Time, or the
+// zero time when the field is absent.
+func (i Input) DateValue(name string) time.Time {
+	if v, ok := i[name].(time.Time); ok {
+		return v
+	}
+	return time.Time{}
+}
+
+// TimeValue returns a bound Time field as time.Time on January 1, year 0,
+// or the zero time when the field is absent.
+func (i Input) TimeValue(name string) time.Time {
+	return i.DateValue(name)
+}
+
+// timeString returns a string field as is and a time.Time formatted with layout.
+func (i Input) timeString(name, layout string) string {
+	switch v := i[name].(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(layout)
+	}
+	return ""
+}
+
+// layouts returns the layouts accepted by f.
+func (f Param) layouts() []string {
+	if len(f.Layouts) > 0 {
+		return f.Layouts
+	}
+	switch f.Type {
+	case Date:
+		return []string{DateLayout}
+	case Time:
+		return []string{TimeLayout}
+	}
+	return []string{DateTimeLayout}
+}
+
+// location returns the zone f's values are bound in.
+func (f Param) location() *time.Location {
+	if f.Location != nil {
+		return f.Location
+	}
+	return time.UTC
+}
+
+// timeType parses strings with the field's layouts and returns them in
+// the field's location. time.Time values, e.g. from DefaultFunc, are
+// accepted as is. The validator tag applies to the submitted string.
+type timeType struct{}
+
+func (timeType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	loc := b.location()
+	switch v := val.(type) {
+	case time.Time:
+		return v.In(loc), true
+	case string:
+		if !b.ValidateTag(v) {
+			return nil, false
+		}
+		for _, layout := range b.layouts() {
+			if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+				return t.In(loc), true
+			}
+		}
+		b.Fail(CodeTypeMismatch, string(b.Type), val, MsgTimeLayout, string(b.Type), strings.Join(b.layouts(), " | "))
+		return nil, false
+	}
+	b.Mismatch(val, string(b.Type))
+	return nil, false
+}
+
+func (timeType) Validate(*Binding, interface{}) bool { return true }
+
+func (timeType) Describe(f Param) map[string]interface{} {
+	s := map[string]interface{}{"type": "string"}
+	if len(f.Layouts) == 0 {
+		s["format"] = map[FieldType]string{Date: "date", DateTime: "date-time", Time: "time"}[f.Type]
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+// Package grape provides tests for datetime.go functionality.
+//
+// Test Functions:
+// - TestBindAndValidateDateDefaults: Tests the default layouts of Date, DateTime and Time fields
+// - TestBindAndValidateDateLayouts: Tests custom layouts tried in order
+// - TestBindAndValidateDateInvalid: Tests rejection of unparseable values
+// - TestBindAndValidateDateLocation: Tests normalization to UTC and to a configured location
+// - TestBindAndValidateDateDefaultValue: Tests string and time.Time defaults
+// - TestInputDateAccessors: Tests string and time.Time accessors
+package grape
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBindAndValidateDateDefaults(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("birthday").Date()
+	_ = schema.Optional("created_at").DateTime()
+	_ = schema.Optional("opens").Time()
+
+	raw := createTestJSON(`{"birthday": "1990-05-17", "created_at": "2024-03-01T10:30:00+02:00", "opens": "09:15:00"}`)
+	input, err := schema.BindAndValidate(raw, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if d := input.DateValue("birthday"); !d.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 1990-05-17, got %v", d)
+	}
+	created := input.DateValue("created_at")
+	if !created.Equal(time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)) || created.Location() != time.UTC {
+		t.Errorf("Expected 2024-03-01 08:30 UTC, got %v", created)
+	}
+	opens := input.TimeValue("opens")
+	if opens.Hour() != 9 || opens.Minute() != 15 {
+		t.Errorf("Expected 09:15, got %v", opens)
+	}
+}
+
+func TestBindAndValidateDateLayouts(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("day").Date().Layouts("02.01.2006", DateLayout)
+
+	for _, s := range []string{"17.05.1990", "1990-05-17"} {
+		input, err := schema.BindAndValidate(map[string]interface{}{"day": s}, "")
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", s, err)
+		}
+		if d := input.DateValue("day"); d.Year() != 1990 || d.Month() != time.May || d.Day() != 17 {
+			t.Errorf("%s: expected 1990-05-17, got %v", s, d)
+		}
+	}
+}
+
+func TestBindAndValidateDateInvalid(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("day").Date()
+	_ = schema.Optional("at").DateTime()
+	_ = schema.Optional("when").Time()
+
+	raw := createTestJSON(`{"day": "2024-02-30", "at": "yesterday", "when": 930}`)
+	_, err := schema.BindAndValidate(raw, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	for _, e := range verrs {
+		if e.Code != CodeTypeMismatch {
+			t.Errorf("Expected type_mismatch for %s, got %s", e.Field, e.Code)
+		}
+	}
+	if verrs[0].Message != "field 'day' must be date string in format 2006-01-02" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+	if verrs[2].Message != "field 'when' must be time string" {
+		t.Errorf("Unexpected message %q", verrs[2].Message)
+	}
+}
+
+func TestBindAndValidateDateLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	schema := NewParams()
+	_ = schema.Optional("at").DateTime().Layouts(DateTimeLayout, "2006-01-02 15:04").Location(berlin)
+
+	input, err := schema.BindAndValidate(map[string]interface{}{"at": "2024-07-01 12:00"}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	at := input.DateValue("at")
+	if at.Location() != berlin || !at.Equal(time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 12:00 Berlin time, got %v", at)
+	}
+
+	input, err = schema.BindAndValidate(map[string]interface{}{"at": "2024-07-01T12:00:00Z"}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if at := input.DateValue("at"); at.Location() != berlin || at.Hour() != 14 {
+		t.Errorf("Expected 14:00 Berlin time, got %v", at)
+	}
+}
+
+func TestBindAndValidateDateDefaultValue(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	schema := NewParams()
+	_ = schema.Optional("from").Date().Default("2000-01-01")
+	_ = schema.Optional("to").DateTime().DefaultFunc(func(Input) interface{} { return now })
+
+	input, err := schema.BindAndValidate(map[string]interface{}{}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.DateValue("from").Year() != 2000 {
+		t.Errorf("Expected from in 2000, got %v", input["from"])
+	}
+	if !input.DateValue("to").Equal(now) {
+		t.Errorf("Expected to %v, got %v", now, input["to"])
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for unparseable default")
+		}
+	}()
+	_ = NewParams().Optional("from").Date().Default("01/01/2000")
+}
+
+func TestInputDateAccessors(t *testing.T) {
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	input := Input{"day": at, "at": at, "when": at, "raw": "2024-03-01"}
+
+	if input.Date("day") != "2024-03-01" {
+		t.Errorf("Expected '2024-03-01', got %s", input.Date("day"))
+	}
+	if input.DateTime("at") != "2024-03-01T08:30:00Z" {
+		t.Errorf("Expected '2024-03-01T08:30:00Z', got %s", input.DateTime("at"))
+	}
+	if input.Time("when") != "08:30:00" {
+		t.Errorf("Expected '08:30:00', got %s", input.Time("when"))
+	}
+	if input.Date("raw") != "2024-03-01" {
+		t.Errorf("Expected raw string, got %s", input.Date("raw"))
+	}
+	if !input.DateValue("missing").IsZero() {
+		t.Errorf("Expected zero time, got %v", input.DateValue("missing"))
+	}
+}
@@ -0,0 +1,180 @@
+package grape
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an arbitrary-precision decimal number bound from a BigDecimal
+// field. The zero value is 0.
+type Decimal struct {
+	rat   *big.Rat
+	scale int
+}
+
+// maxDecimalExponent bounds exponents accepted by ParseDecimal so a short
+// input such as "1e999999999" cannot allocate a huge number.
+const maxDecimalExponent = 1000
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// ParseDecimal parses a decimal number such as "12.50", "-0.001" or "1e-3".
+// The scale, the number of digits after the decimal point, is kept so
+// "12.50" prints back as "12.50".
+func ParseDecimal(s string) (Decimal, error) {
+	invalid := errors.New("grape: invalid decimal " + strconv.Quote(s))
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, invalid
+	}
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		var err error
+		if exp, err = strconv.Atoi(s[i+1:]); err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return Decimal{}, invalid
+		}
+	}
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, invalid
+	}
+
+	scale := 0
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = len(mantissa) - i - 1
+	}
+	scale -= exp
+	if scale < 0 {
+		scale = 0
+	}
+	return Decimal{rat: rat, scale: scale}, nil
+}
+
+// Rat returns the value as a new *big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(d.rat)
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int { return d.scale }
+
+// Precision returns the number of digits, ignoring leading zeros of the
+// integer part.
+func (d Decimal) Precision() int {
+	digits := strings.TrimLeft(strings.Replace(strings.TrimPrefix(d.String(), "-"), ".", "", 1), "0")
+	if len(digits) < d.scale {
+		return d.scale
+	}
+	return len(digits)
+}
+
+func (d Decimal) String() string {
+	if d.rat == nil {
+		return "0"
+	}
+	return d.rat.FloatString(d.scale)
+}
+
+// MarshalJSON encodes d as a JSON string so clients do not round it.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Precision limits a BigDecimal field to n digits in total.
+func (f *FieldBuilder) Precision(n int) *FieldBuilder {
+	f.param.Precision = n
+	f.updateParent()
+	return f
+}
+
+// Scale limits a BigDecimal field to n digits after the decimal point.
+func (f *FieldBuilder) Scale(n int) *FieldBuilder {
+	f.param.Scale = &n
+	f.updateParent()
+	return f
+}
+
+// Decimal returns a bound BigDecimal field, or the zero Decimal when the
+// field is absent.
+func (i Input) Decimal(name string) Decimal {
+	if v, ok := i[name].(Decimal); ok {
+		return v
+	}
+	return Decimal{}
+}
+
+// decimalType parses values into a Decimal and applies the validator tag,
+// precision and scale. Numbers decoded by BindAndValidateReader keep their
+// exact text; float64 values use their shortest representation.
+type decimalType struct{}
+
+func (decimalType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	var s string
+	switch v := val.(type) {
+	case Decimal:
+		s = v.String()
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.TrimSpace(v)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b.Mismatch(val, string(BigDecimal))
+		return nil, false
+	}
+
+	d, err := ParseDecimal(s)
+	if err != nil {
+		b.Mismatch(val, string(BigDecimal))
+		return nil, false
+	}
+	return d, true
+}
+
+func (decimalType) Validate(b *Binding, val interface{}) bool {
+	d := val.(Decimal)
+	if !b.ValidateTag(d.String()) {
+		return false
+	}
+	if b.Scale != nil && d.Scale() > *b.Scale {
+		b.Fail(CodeValidationFailed+":scale", "scale", d.String(), MsgScale, strconv.Itoa(*b.Scale))
+		return false
+	}
+	if b.Precision > 0 && d.Precision() > b.Precision {
+		b.Fail(CodeValidationFailed+":precision", "precision", d.String(), MsgPrecision, strconv.Itoa(b.Precision))
+		return false
+	}
+	return true
+}
+
+func (decimalType) Describe(f Param) map[string]interface{} {
+	return map[string]interface{}{"type": []interface{}{"string", "number"}, "format": "decimal"}
+}
+
+// plainNumbers replaces json.Number values in v, recursively, with float64
+// so untyped values look the same as with encoding/json defaults.
+func plainNumbers(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case json.Number:
+		if f, err := vv.Float64(); err == nil {
+			return f
+		}
+	case map[string]interface{}:
+		for k, e := range vv {
+			vv[k] = plainNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = plainNumbers(e)
+		}
+	}
+	return v
+}
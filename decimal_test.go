@@ -0,0 +1,173 @@
+// Package grape provides tests for decimal.go functionality.
+//
+// Test Functions:
+// - TestParseDecimal: Tests parsing, scale and precision of decimal strings
+// - TestParseDecimalInvalid: Tests rejection of malformed decimals and huge exponents
+// - TestDecimalMarshalJSON: Tests JSON encoding as a string
+// - TestBindAndValidateReaderBigDecimalExact: Tests exact decoding of large numbers via UseNumber
+// - TestBindAndValidateBigDecimalInputs: Tests string, float and invalid inputs
+// - TestBindAndValidateBigDecimalPrecisionScale: Tests precision and scale rules
+// - TestBindAndValidateReaderUntypedNumbers: Tests that untyped numbers still decode as float64
+package grape
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in        string
+		str       string
+		scale     int
+		precision int
+	}{
+		{"12.50", "12.50", 2, 4},
+		{"-0.001", "-0.001", 3, 3},
+		{"100", "100", 0, 3},
+		{"1e-3", "0.001", 3, 3},
+		{"1.5E2", "150", 0, 3},
+		{".5", "0.5", 1, 1},
+		{"+7", "7", 0, 1},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.in, err)
+			continue
+		}
+		if d.String() != tt.str || d.Scale() != tt.scale || d.Precision() != tt.precision {
+			t.Errorf("%s: expected %s scale %d precision %d, got %s scale %d precision %d",
+				tt.in, tt.str, tt.scale, tt.precision, d.String(), d.Scale(), d.Precision())
+		}
+	}
+
+	d, _ := ParseDecimal("0.1")
+	if d.Rat().Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("Expected exactly 1/10, got %v", d.Rat())
+	}
+	if (Decimal{}).String() != "0" {
+		t.Errorf("Expected zero Decimal to print 0, got %s", Decimal{}.String())
+	}
+}
+
+func TestParseDecimalInvalid(t *testing.T) {
+	for _, s := range []string{"", "abc", "1/3", "1,5", "0x10", "1e", "1e99999", "NaN", "Inf"} {
+		if _, err := ParseDecimal(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	d, _ := ParseDecimal("19.90")
+	b, err := json.Marshal(map[string]interface{}{"price": d})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(b) != `{"price":"19.90"}` {
+		t.Errorf("Expected price as string, got %s", b)
+	}
+}
+
+func TestBindAndValidateReaderBigDecimalExact(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("amount").BigDecimal()
+
+	body := `{"amount": 12345678901234567890.123456789}`
+	input, err := schema.BindAndValidateReader(strings.NewReader(body), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.BigDecimal("amount") != "12345678901234567890.123456789" {
+		t.Errorf("Expected exact amount, got %s", input.BigDecimal("amount"))
+	}
+	expected, _ := new(big.Rat).SetString("12345678901234567890.123456789")
+	if input.Decimal("amount").Rat().Cmp(expected) != 0 {
+		t.Errorf("Expected exact rational, got %v", input.Decimal("amount").Rat())
+	}
+}
+
+func TestBindAndValidateBigDecimalInputs(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("amount").BigDecimal()
+
+	tests := map[interface{}]string{
+		"19.99":    "19.99",
+		0.1:        "0.1",
+		float64(5): "5",
+	}
+	for in, expected := range tests {
+		input, err := schema.BindAndValidate(map[string]interface{}{"amount": in}, "")
+		if err != nil {
+			t.Errorf("%v: expected no error, got %v", in, err)
+			continue
+		}
+		if input.BigDecimal("amount") != expected {
+			t.Errorf("%v: expected %s, got %s", in, expected, input.BigDecimal("amount"))
+		}
+	}
+
+	for _, in := range []interface{}{"twelve", true, "1/3"} {
+		_, err := schema.BindAndValidate(map[string]interface{}{"amount": in}, "")
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || verrs[0].Code != CodeTypeMismatch {
+			t.Errorf("%v: expected type mismatch, got %v", in, err)
+		}
+	}
+}
+
+func TestBindAndValidateBigDecimalPrecisionScale(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("price").BigDecimal().Precision(6).Scale(2)
+
+	if _, err := schema.BindAndValidateReader(strings.NewReader(`{"price": 1234.56}`), ""); err != nil {
+		t.Errorf("Expected 1234.56 to pass, got %v", err)
+	}
+
+	_, err := schema.BindAndValidateReader(strings.NewReader(`{"price": 12.345}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != "validation_failed:scale" {
+		t.Fatalf("Expected scale error, got %v", err)
+	}
+	if verrs[0].Message != "field 'price' must have at most 2 decimal places" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+
+	_, err = schema.BindAndValidateReader(strings.NewReader(`{"price": "123456.7"}`), "")
+	verrs, ok = err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != "validation_failed:precision" {
+		t.Errorf("Expected precision error, got %v", err)
+	}
+
+	integers := NewParams()
+	_ = integers.Optional("cents").BigDecimal().Scale(0)
+	if _, err := integers.BindAndValidate(map[string]interface{}{"cents": "10.5"}, ""); err == nil {
+		t.Error("Expected scale 0 to reject decimal places")
+	}
+}
+
+func TestBindAndValidateReaderUntypedNumbers(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("count").Integer()
+	_ = schema.Optional("ratio").Float()
+	_ = schema.Optional("meta").JSON()
+
+	body := `{"count": 3, "ratio": 0.25, "meta": {"n": 1, "list": [2]}, "extra": 4}`
+	input, err := schema.BindAndValidateReader(strings.NewReader(body), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("count", 0) != 3 || input.Float("ratio", 0) != 0.25 {
+		t.Errorf("Expected count 3 and ratio 0.25, got %v and %v", input["count"], input["ratio"])
+	}
+	meta := input["meta"].(map[string]interface{})
+	if meta["n"] != 1.0 || meta["list"].([]interface{})[0] != 2.0 {
+		t.Errorf("Expected float64 numbers in JSON field, got %#v", meta)
+	}
+	if input["extra"] != 4.0 {
+		t.Errorf("Expected float64 extra, got %#v", input["extra"])
+	}
+}
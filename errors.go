@@ -0,0 +1,148 @@
+package grape
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Error codes carried by FieldError.Code. Validator tag failures use
+// CodeValidationFailed followed by ":" and the tag, e.g. "validation_failed:email".
+const (
+	CodeRequired         = "required"
+	CodeTypeMismatch     = "type_mismatch"
+	CodeValidationFailed = "validation_failed"
+	CodeUnknownField     = "unknown_field"
+	CodeCoercionFailed   = "coercion_failed"
+)
+
+// FieldError describes a single field that failed binding or validation.
+type FieldError struct {
+	Field   string // declared field name
+	Path    string // RFC 6901 JSON pointer from the root, e.g. "/items/3/sku"
+	Code    string // stable machine-readable code, see the Code constants
+	Rule    string // failed rule: "required", the expected type or a validator tag
+	Message string
+	Value   interface{} // rejected value, nil when the field was missing
+
+	key  string               // message key, see the Msg constants
+	args []string             // message arguments
+	verr validator.FieldError // underlying validator failure, if any
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" || e.Path == pointer("", e.Field) {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors collects every FieldError produced by one bind call.
+type ValidationErrors []*FieldError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// add records a failure for the named field at path. The message is
+// rendered from key and args once binding finishes.
+func (ve *ValidationErrors) add(code, name, path, rule string, val interface{}, key string, args ...string) *FieldError {
+	e := &FieldError{Field: name, Path: path, Code: code, Rule: rule, Value: val, key: key, args: args}
+	*ve = append(*ve, e)
+	return e
+}
+
+// required records a missing required field.
+func (ve *ValidationErrors) required(name, path, mode string) {
+	ve.add(CodeRequired, name, path, "required", nil, MsgRequired, name, mode)
+}
+
+// mismatch records a value of the wrong type; typ names the expected type.
+func (ve *ValidationErrors) mismatch(name, path, typ string, val interface{}) {
+	ve.add(CodeTypeMismatch, name, path, typ, val, MsgTypeMismatch, name, typ)
+}
+
+// nest appends errors reported by a child schema, prefixing their paths with
+// the child's pointer.
+func (ve *ValidationErrors) nest(prefix string, child ValidationErrors) {
+	for _, e := range child {
+		e.Path = prefix + e.Path
+		*ve = append(*ve, e)
+	}
+}
+
+// validateValue runs the validator tag against val. The validator panics
+// on rules that don't fit the value's kind, such as oneof on a slice; that
+// is returned as an error so no value can panic a request.
+func validateValue(val interface{}, tag string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return validate.Var(val, tag)
+}
+
+// validateVar runs f's validator tag against val and records a failure at
+// path. It reports whether val passed.
+func (ve *ValidationErrors) validateVar(f Param, path string, val interface{}) bool {
+	if f.Validate == "" {
+		return true
+	}
+	err := validateValue(val, f.Validate)
+	if err == nil {
+		return true
+	}
+	rule := f.Validate
+	var verr validator.FieldError
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) && len(verrs) > 0 {
+		verr = verrs[0]
+		rule = verr.Tag()
+	}
+	e := ve.add(CodeValidationFailed+":"+rule, f.Name, path, rule, val, MsgValidationFailed, f.Name, err.Error())
+	e.verr = verr
+	return false
+}
+
+// checkValues records a failure at path when f restricts its values and val
+// is not one of them. It reports whether val passed.
+func (ve *ValidationErrors) checkValues(f Param, path string, val interface{}) bool {
+	if len(f.Values) == 0 {
+		return true
+	}
+	allowed := make([]string, len(f.Values))
+	for i, v := range f.Values {
+		if sameValue(v, val) {
+			return true
+		}
+		allowed[i] = fmt.Sprint(v)
+	}
+	ve.add(CodeValidationFailed+":values", f.Name, path, "values", val, MsgNotAllowed, f.Name, strings.Join(allowed, ", "))
+	return false
+}
+
+// sameValue reports whether an allowed value matches a bound one. Integers
+// compare exactly; other numbers compare as float64.
+func sameValue(allowed, val interface{}) bool {
+	if a, ok := bigInteger(allowed); ok {
+		if b, ok := bigInteger(val); ok {
+			return a.Cmp(b) == 0
+		}
+	}
+	return jsonNumber(allowed) == jsonNumber(val)
+}
+
+// pointerEscaper escapes reference tokens as required by RFC 6901.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointer appends token to the JSON pointer parent.
+func pointer(parent, token string) string {
+	return parent + "/" + pointerEscaper.Replace(token)
+}
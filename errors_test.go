@@ -0,0 +1,138 @@
+// Package grape provides tests for errors.go functionality.
+//
+// Test Functions:
+// - TestValidationErrorsCollectsAllFields: Tests that every failing field is reported
+// - TestValidationErrorsErrorsAs: Tests extraction with errors.As from both entry points
+// - TestValidationErrorsNestedPath: Tests JSON pointers and codes for nested schema and slice failures
+// - TestValidationErrorsPointerEscaping: Tests RFC 6901 escaping of '~' and '/' in pointers
+// - TestValidationErrorsMessage: Tests the joined error message
+package grape
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrorsCollectsAllFields(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("name").On("create").String()
+	_ = schema.Optional("age").Integer()
+	_ = schema.Optional("email").String().Validate("email")
+
+	raw := createTestJSON(`{"age": "thirty", "email": "nope"}`)
+	_, err := schema.BindAndValidate(raw, "create")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	verrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %T", err)
+	}
+	if len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %d: %v", len(verrs), verrs)
+	}
+
+	expected := []struct {
+		field string
+		code  string
+		rule  string
+		value interface{}
+	}{
+		{"name", CodeRequired, "required", nil},
+		{"age", CodeTypeMismatch, "integer", "thirty"},
+		{"email", "validation_failed:email", "email", "nope"},
+	}
+	for i, e := range expected {
+		if verrs[i].Field != e.field || verrs[i].Path != "/"+e.field {
+			t.Errorf("Error %d: expected field %s, got %s (%s)", i, e.field, verrs[i].Field, verrs[i].Path)
+		}
+		if verrs[i].Code != e.code {
+			t.Errorf("Error %d: expected code %s, got %s", i, e.code, verrs[i].Code)
+		}
+		if verrs[i].Rule != e.rule {
+			t.Errorf("Error %d: expected rule %s, got %s", i, e.rule, verrs[i].Rule)
+		}
+		if verrs[i].Value != e.value {
+			t.Errorf("Error %d: expected value %v, got %v", i, e.value, verrs[i].Value)
+		}
+	}
+}
+
+func TestValidationErrorsErrorsAs(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("name").On("create").String()
+
+	_, err := schema.BindAndValidateReader(strings.NewReader(`{}`), "create")
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected errors.As to find ValidationErrors in %v", err)
+	}
+	if len(verrs) != 1 || verrs[0].Field != "name" {
+		t.Errorf("Expected single error for name, got %v", verrs)
+	}
+
+	_, err = schema.validateJSON(map[string]interface{}{}, "create")
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected errors.As to find ValidationErrors in %v", err)
+	}
+}
+
+func TestValidationErrorsNestedPath(t *testing.T) {
+	item := NewParams()
+	_ = item.Requires("sku").On("create").String()
+	_ = item.Optional("qty").Integer()
+	schema := NewParams()
+	_ = schema.Optional("items").SliceOf(JSON, item)
+
+	raw := createTestJSON(`{"items": [{"sku": "a"}, {"qty": 1}, 5, {"sku": 7}]}`)
+	_, err := schema.BindAndValidate(raw, "create")
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	paths := []string{}
+	for _, e := range verrs {
+		paths = append(paths, e.Path)
+	}
+	expected := []string{"/items/1/sku", "/items/2", "/items/3/sku"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected paths %v, got %v", expected, paths)
+	}
+	if verrs[0].Field != "sku" {
+		t.Errorf("Expected field 'sku', got %s", verrs[0].Field)
+	}
+	if verrs[1].Code != CodeTypeMismatch || verrs[2].Code != CodeTypeMismatch {
+		t.Errorf("Expected type_mismatch codes, got %s and %s", verrs[1].Code, verrs[2].Code)
+	}
+}
+
+func TestValidationErrorsPointerEscaping(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Requires("a/b").On("create").String()
+	schema := NewParams()
+	_ = schema.Optional("x~y").JSON().WithSchema(sub)
+
+	raw := createTestJSON(`{"x~y": {}}`)
+	_, err := schema.BindAndValidate(raw, "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 {
+		t.Fatalf("Expected one error, got %v", err)
+	}
+	if verrs[0].Path != "/x~0y/a~1b" {
+		t.Errorf("Expected escaped pointer '/x~0y/a~1b', got %s", verrs[0].Path)
+	}
+}
+
+func TestValidationErrorsMessage(t *testing.T) {
+	verrs := ValidationErrors{
+		{Field: "name", Path: "/name", Message: "missing required field 'name' for create"},
+		{Field: "city", Path: "/address/city", Message: "field 'city' must be string"},
+	}
+	expected := "missing required field 'name' for create; /address/city: field 'city' must be string"
+	if verrs.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, verrs.Error())
+	}
+}
@@ -2,12 +2,14 @@ module github.com/krivospitsky/grape
 
 go 1.25.1
 
-require github.com/go-playground/validator/v10 v10.27.0
+require (
+	github.com/gabriel-vasile/mimetype v1.4.10
+	github.com/go-playground/locales v0.14.1
+	github.com/go-playground/universal-translator v0.18.1
+	github.com/go-playground/validator/v10 v10.27.0
+)
 
 require (
-	github.com/gabriel-vasile/mimetype v1.4.10 // indirect
-	github.com/go-playground/locales v0.14.1 // indirect
-	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/stretchr/testify v1.11.1 // indirect
 	golang.org/x/crypto v0.42.0 // indirect
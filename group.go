@@ -0,0 +1,111 @@
+package grape
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Group rules, as in Ruby Grape's parameter group validators. Each rule is
+// also the message key of its error; {0} lists the fields.
+const (
+	MutuallyExclusive = "mutually_exclusive" // at most one field present
+	ExactlyOneOf      = "exactly_one_of"     // exactly one field present
+	AtLeastOneOf      = "at_least_one_of"    // one or more fields present
+	AllOrNoneOf       = "all_or_none_of"     // every field present, or none
+)
+
+// Group constrains which of several fields may be submitted together.
+// A field counts as present when its key is submitted; defaults do not
+// count.
+type Group struct {
+	Rule   string
+	Fields []string
+	On     []string // modes the rule applies to; empty means all
+}
+
+type GroupBuilder struct {
+	index  int
+	parent *Params
+}
+
+// MutuallyExclusive allows at most one of the named fields.
+func (p *Params) MutuallyExclusive(names ...string) *GroupBuilder {
+	return p.addGroup(MutuallyExclusive, names)
+}
+
+// ExactlyOneOf requires exactly one of the named fields.
+func (p *Params) ExactlyOneOf(names ...string) *GroupBuilder {
+	return p.addGroup(ExactlyOneOf, names)
+}
+
+// AtLeastOneOf requires one or more of the named fields.
+func (p *Params) AtLeastOneOf(names ...string) *GroupBuilder {
+	return p.addGroup(AtLeastOneOf, names)
+}
+
+// AllOrNoneOf requires the named fields to be submitted together or not at all.
+func (p *Params) AllOrNoneOf(names ...string) *GroupBuilder {
+	return p.addGroup(AllOrNoneOf, names)
+}
+
+func (p *Params) addGroup(rule string, names []string) *GroupBuilder {
+	if len(names) < 2 {
+		panic(fmt.Sprintf("grape: %s needs at least two fields", rule))
+	}
+	for _, name := range names {
+		if !p.declares(name) {
+			panic(fmt.Sprintf("grape: %s names undeclared field '%s'", rule, name))
+		}
+	}
+	p.Groups = append(p.Groups, Group{Rule: rule, Fields: names})
+	return &GroupBuilder{index: len(p.Groups) - 1, parent: p}
+}
+
+// On limits the group rule to the given modes.
+func (g *GroupBuilder) On(modes ...string) *GroupBuilder {
+	grp := &g.parent.Groups[g.index]
+	grp.On = append(grp.On, modes...)
+	return g
+}
+
+// checkGroups applies p's group rules for mode to the keys submitted in
+// raw. Failures are recorded on the group, at the path of p itself.
+func (p *Params) checkGroups(raw map[string]interface{}, mode string, errs *ValidationErrors) {
+	for _, g := range p.Groups {
+		if len(g.On) > 0 && !containsString(g.On, mode) {
+			continue
+		}
+		var present []string
+		names := make([]string, len(g.Fields))
+		for i, name := range g.Fields {
+			f, ok := p.field(name)
+			if !ok {
+				f = Param{Name: name}
+			}
+			names[i] = f.external().Name
+			if _, ok := f.lookup(raw); ok {
+				present = append(present, names[i])
+			}
+		}
+		var ok bool
+		switch g.Rule {
+		case MutuallyExclusive:
+			ok = len(present) <= 1
+		case ExactlyOneOf:
+			ok = len(present) == 1
+		case AtLeastOneOf:
+			ok = len(present) >= 1
+		case AllOrNoneOf:
+			ok = len(present) == 0 || len(present) == len(g.Fields)
+		}
+		if ok {
+			continue
+		}
+		quoted := make([]string, len(names))
+		for i, name := range names {
+			quoted[i] = "'" + name + "'"
+		}
+		errs.add(CodeValidationFailed+":"+g.Rule, strings.Join(names, ","), "", g.Rule, present,
+			g.Rule, strings.Join(quoted, ", "))
+	}
+}
@@ -0,0 +1,149 @@
+// Package grape provides tests for group.go functionality.
+//
+// Test Functions:
+// - TestGroupRules: Tests each group rule against combinations of submitted fields
+// - TestGroupError: Tests the code, field, path and message of group errors
+// - TestGroupOnModes: Tests restricting a group rule to modes
+// - TestGroupNested: Tests group rules inside nested schemas
+// - TestGroupIgnoresDefaults: Tests that defaults do not count as submitted
+// - TestGroupNeedsTwoFields: Tests the definition-time field count check
+// - TestGroupUndeclaredField: Tests the definition-time check of field names
+package grape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGroupRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		body string
+		ok   bool
+	}{
+		{MutuallyExclusive, `{}`, true},
+		{MutuallyExclusive, `{"a": 1}`, true},
+		{MutuallyExclusive, `{"a": 1, "b": 2}`, false},
+		{ExactlyOneOf, `{}`, false},
+		{ExactlyOneOf, `{"b": 2}`, true},
+		{ExactlyOneOf, `{"a": 1, "c": 3}`, false},
+		{AtLeastOneOf, `{}`, false},
+		{AtLeastOneOf, `{"a": 1, "c": 3}`, true},
+		{AllOrNoneOf, `{}`, true},
+		{AllOrNoneOf, `{"a": 1, "b": 2, "c": 3}`, true},
+		{AllOrNoneOf, `{"a": 1}`, false},
+	}
+	for _, tt := range tests {
+		schema := NewParams()
+		_ = schema.Optional("a").Integer()
+		_ = schema.Optional("b").Integer()
+		_ = schema.Optional("c").Integer()
+		schema.addGroup(tt.rule, []string{"a", "b", "c"})
+
+		_, err := schema.BindAndValidate(createTestJSON(tt.body), "")
+		if (err == nil) != tt.ok {
+			t.Errorf("%s %s: expected ok=%v, got %v", tt.rule, tt.body, tt.ok, err)
+		}
+	}
+}
+
+func TestGroupError(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("email").String()
+	_ = schema.Optional("phone").String()
+	_ = schema.Optional("age").Integer()
+	schema.MutuallyExclusive("email", "phone")
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"email": "a@b.c", "phone": "1", "age": "x"}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected field and group errors, got %v", err)
+	}
+	e := verrs[1]
+	if e.Code != "validation_failed:mutually_exclusive" || e.Rule != MutuallyExclusive {
+		t.Errorf("Unexpected code %s, rule %s", e.Code, e.Rule)
+	}
+	if e.Field != "email,phone" || e.Path != "" {
+		t.Errorf("Expected group field and root path, got %q and %q", e.Field, e.Path)
+	}
+	if !reflect.DeepEqual(e.Value, []string{"email", "phone"}) {
+		t.Errorf("Expected submitted fields as value, got %v", e.Value)
+	}
+	if e.Error() != "fields 'email', 'phone' are mutually exclusive" {
+		t.Errorf("Unexpected message %q", e.Error())
+	}
+
+	_, err = schema.Locale("ru").BindAndValidate(createTestJSON(`{"email": "a@b.c", "phone": "1"}`), "")
+	if err == nil || err.Error() != "поля 'email', 'phone' взаимно исключают друг друга" {
+		t.Errorf("Expected Russian group message, got %v", err)
+	}
+}
+
+func TestGroupOnModes(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("id").Integer()
+	_ = schema.Optional("slug").String()
+	schema.ExactlyOneOf("id", "slug").On("update")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{}`), "create"); err != nil {
+		t.Errorf("Expected rule to be skipped for create, got %v", err)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{}`), "update"); err == nil {
+		t.Error("Expected error for update")
+	}
+	if len(schema.Groups) != 1 || !reflect.DeepEqual(schema.Groups[0].On, []string{"update"}) {
+		t.Errorf("Expected introspectable group, got %+v", schema.Groups)
+	}
+}
+
+func TestGroupNested(t *testing.T) {
+	shipping := NewParams()
+	_ = shipping.Optional("address").String()
+	_ = shipping.Optional("pickup_point").String()
+	shipping.AtLeastOneOf("address", "pickup_point")
+
+	schema := NewParams()
+	_ = schema.Optional("shipping").JSON().WithSchema(shipping)
+	_ = schema.Optional("parcels").SliceOf(JSON, shipping)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"shipping": {}, "parcels": [{"address": "x"}, {}]}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", err)
+	}
+	if verrs[0].Path != "/shipping" || verrs[1].Path != "/parcels/1" {
+		t.Errorf("Expected errors at the nested objects, got %s and %s", verrs[0].Path, verrs[1].Path)
+	}
+	if !strings.HasPrefix(verrs[0].Error(), "/shipping: at least one of 'address', 'pickup_point'") {
+		t.Errorf("Unexpected error %q", verrs[0].Error())
+	}
+}
+
+func TestGroupIgnoresDefaults(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("limit").Integer().Default(10)
+	_ = schema.Optional("cursor").String()
+	schema.ExactlyOneOf("limit", "cursor")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"cursor": "abc"}`), ""); err != nil {
+		t.Errorf("Expected default not to count, got %v", err)
+	}
+}
+
+func TestGroupNeedsTwoFields(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a single field")
+		}
+	}()
+	NewParams().AllOrNoneOf("a")
+}
+
+func TestGroupUndeclaredField(t *testing.T) {
+	expectDefinitionPanic(t, "undeclared field", func() {
+		schema := NewParams()
+		_ = schema.Optional("a").String()
+		schema.ExactlyOneOf("a", "typo")
+	})
+}
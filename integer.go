@@ -0,0 +1,130 @@
+package grape
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Inclusive ranges of the integer field types.
+var (
+	minInt    = big.NewInt(math.MinInt)
+	maxInt    = big.NewInt(math.MaxInt)
+	minInt64  = big.NewInt(math.MinInt64)
+	maxInt64  = big.NewInt(math.MaxInt64)
+	minUint   = new(big.Int)
+	maxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+// Int64 binds whole numbers into int64.
+func (f *FieldBuilder) Int64() *FieldBuilder { f.param.Type = Int64; f.updateParent(); return f }
+
+// Uint binds non-negative whole numbers into uint64.
+func (f *FieldBuilder) Uint() *FieldBuilder { f.param.Type = Uint; f.updateParent(); return f }
+
+func (i Input) Int64(name string, def int64) int64 {
+	if v, ok := i[name].(int64); ok {
+		return v
+	}
+	return def
+}
+
+func (i Input) Uint(name string, def uint64) uint64 {
+	if v, ok := i[name].(uint64); ok {
+		return v
+	}
+	return def
+}
+
+// isInteger reports whether t is Integer, Int64 or Uint.
+func isInteger(t FieldType) bool {
+	return t == Integer || t == Int64 || t == Uint
+}
+
+// integerRange returns the inclusive range of the integer type t.
+func integerRange(t FieldType) (min, max *big.Int) {
+	switch t {
+	case Int64:
+		return minInt64, maxInt64
+	case Uint:
+		return minUint, maxUint64
+	}
+	return minInt, maxInt
+}
+
+// bigInteger converts val to an exact integer. It reports false for values
+// that are not numbers or not whole.
+func bigInteger(val interface{}) (*big.Int, bool) {
+	switch v := val.(type) {
+	case json.Number:
+		d, err := ParseDecimal(v.String())
+		if err != nil || !d.rat.IsInt() {
+			return nil, false
+		}
+		return new(big.Int).Set(d.rat.Num()), true
+	case float64:
+		if math.IsInf(v, 0) || v != math.Trunc(v) {
+			return nil, false
+		}
+		n, _ := big.NewFloat(v).Int(nil)
+		return n, true
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+// integerType converts whole numbers into the Go type of Integer, Int64
+// or Uint fields. Non-whole numbers are type mismatches; numbers decoded
+// by BindAndValidateReader are read exactly, so IDs beyond 2^53 keep every
+// digit.
+type integerType struct{}
+
+func (integerType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	n, ok := bigInteger(val)
+	if !ok {
+		b.Mismatch(val, string(b.Type))
+		return nil, false
+	}
+	min, max := integerRange(b.Type)
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		b.Fail(CodeValidationFailed+":range", "range", val, MsgOutOfRange, min.String(), max.String())
+		return nil, false
+	}
+	switch b.Type {
+	case Int64:
+		return n.Int64(), true
+	case Uint:
+		return n.Uint64(), true
+	}
+	return int(n.Int64()), true
+}
+
+func (integerType) Validate(b *Binding, val interface{}) bool {
+	return b.ValidateTag(val) && b.CheckValues(val)
+}
+
+func (integerType) Describe(f Param) map[string]interface{} {
+	s := map[string]interface{}{"type": "integer"}
+	switch f.Type {
+	case Int64:
+		s["format"] = "int64"
+	case Uint:
+		s["minimum"] = 0
+	}
+	return s
+}
+
+// CoerceString keeps integers as json.Number so large values stay exact.
+func (integerType) CoerceString(s string) interface{} {
+	if _, ok := new(big.Int).SetString(s, 10); ok {
+		return json.Number(s)
+	}
+	return s
+}
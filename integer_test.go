@@ -0,0 +1,151 @@
+// Package grape provides tests for integer.go functionality.
+//
+// Test Functions:
+// - TestBindAndValidateIntegerRejectsFractions: Tests that non-whole numbers are type mismatches
+// - TestBindAndValidateIntegerRange: Tests overflow detection for Integer, Int64 and Uint
+// - TestBindAndValidateReaderLargeIDs: Tests exact decoding of integers beyond 2^53
+// - TestBindAndValidateIntegerAppliesTag: Tests that the validator tag runs for every input kind
+// - TestIntegerValues: Tests allowed values on Int64 and Uint fields
+// - TestBindQueryLargeIntegers: Tests exact coercion of large query integers
+package grape
+
+import (
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBindAndValidateIntegerRejectsFractions(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("count").Integer()
+
+	for _, body := range []string{`{"count": 3.7}`, `{"count": "3"}`, `{"count": 1e-2}`} {
+		_, err := schema.BindAndValidateReader(strings.NewReader(body), "")
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || verrs[0].Code != CodeTypeMismatch {
+			t.Errorf("%s: expected type mismatch, got %v", body, err)
+		}
+	}
+
+	_, err := schema.BindAndValidate(map[string]interface{}{"count": 3.7}, "")
+	if err == nil || err.Error() != "field 'count' must be integer" {
+		t.Errorf("Expected type mismatch for float64 3.7, got %v", err)
+	}
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"count": 1.5e1}`), "")
+	if err != nil || input.Integer("count", 0) != 15 {
+		t.Errorf("Expected whole exponent form to bind as 15, got %v, %v", input, err)
+	}
+}
+
+func TestBindAndValidateIntegerRange(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("n").Integer()
+	_ = schema.Optional("id").Int64()
+	_ = schema.Optional("size").Uint()
+
+	body := `{"n": 9223372036854775808, "id": -9223372036854775809, "size": -1}`
+	_, err := schema.BindAndValidateReader(strings.NewReader(body), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	for _, e := range verrs {
+		if e.Code != "validation_failed:range" {
+			t.Errorf("Expected range error for %s, got %s", e.Field, e.Code)
+		}
+	}
+	if verrs[2].Message != "field 'size' must be between 0 and 18446744073709551615" {
+		t.Errorf("Unexpected message %q", verrs[2].Message)
+	}
+
+	// 2^63 is exactly representable as float64 and must not wrap around
+	_, err = schema.BindAndValidate(map[string]interface{}{"id": math.Pow(2, 63)}, "")
+	if err == nil {
+		t.Error("Expected overflow error for float64 2^63")
+	}
+
+	body = `{"n": -5, "id": 9223372036854775807, "size": 18446744073709551615}`
+	input, err := schema.BindAndValidateReader(strings.NewReader(body), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("n", 0) != -5 || input.Int64("id", 0) != math.MaxInt64 || input.Uint("size", 0) != math.MaxUint64 {
+		t.Errorf("Unexpected values %#v", input)
+	}
+}
+
+func TestBindAndValidateReaderLargeIDs(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("id").Int64()
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"id": 9007199254740993}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Int64("id", 0) != 9007199254740993 {
+		t.Errorf("Expected exact id, got %d", input.Int64("id", 0))
+	}
+	if input.Int64("missing", 7) != 7 || input.Uint("id", 7) != 7 {
+		t.Error("Expected defaults for missing or differently typed fields")
+	}
+}
+
+func TestBindAndValidateIntegerAppliesTag(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("age").Integer().Validate("min=18")
+	_ = schema.Optional("id").Uint().Validate("max=100")
+
+	for _, raw := range []map[string]interface{}{
+		{"age": 3},
+		{"age": 3.0},
+		{"age": int64(3)},
+		{"id": uint64(101)},
+	} {
+		_, err := schema.BindAndValidate(raw, "")
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || !strings.HasPrefix(verrs[0].Code, "validation_failed:m") {
+			t.Errorf("%v: expected validator error, got %v", raw, err)
+		}
+	}
+}
+
+func TestIntegerValues(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("id").Int64().Values(int64(math.MaxInt64), 1)
+	_ = schema.Optional("n").Uint().Values(1, 2)
+
+	if _, err := schema.BindAndValidateReader(strings.NewReader(`{"id": 9223372036854775807, "n": 2}`), ""); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	_, err := schema.BindAndValidateReader(strings.NewReader(`{"id": 9223372036854775806}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != "validation_failed:values" {
+		t.Errorf("Expected values error, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for fractional allowed value")
+		}
+	}()
+	NewParams().Optional("n").Integer().Values(1, 2.5)
+}
+
+func TestBindQueryLargeIntegers(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("id").Uint()
+	_ = schema.Optional("page").Integer()
+
+	input, err := schema.BindQuery(url.Values{"id": {"18446744073709551615"}, "page": {"2"}}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Uint("id", 0) != math.MaxUint64 || input.Integer("page", 0) != 2 {
+		t.Errorf("Unexpected values %#v", input)
+	}
+	if _, err := schema.BindQuery(url.Values{"page": {"2.5"}}, ""); err == nil {
+		t.Error("Expected error for fractional page")
+	}
+}
@@ -0,0 +1,289 @@
+package grape
+
+import (
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	de_translations "github.com/go-playground/validator/v10/translations/de"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	ru_translations "github.com/go-playground/validator/v10/translations/ru"
+)
+
+// Message keys used for grape's own error messages. Catalogs provide a
+// template for each key; {0} is the field name. Type descriptions used by
+// the type mismatch messages are looked up under "type.<name>", and the
+// group rules such as MutuallyExclusive are keys of their own.
+const (
+	MsgRequired            = "required"              // {1} is the mode
+	MsgTypeMismatch        = "type_mismatch"         // {1} is the type description
+	MsgElementTypeMismatch = "element_type_mismatch" // {1} is the type description
+	MsgValidationFailed    = "validation_failed"     // {1} is the validator message
+	MsgTimeLayout          = "time_layout"           // {1} is the type description, {2} lists the layouts
+	MsgNotAllowed          = "not_allowed"           // {1} lists the allowed values
+	MsgOutOfRange          = "out_of_range"          // {1} is the minimum, {2} is the maximum
+	MsgUnknownField        = "unknown_field"         // {0} is the undeclared key
+	MsgCoercionFailed      = "coercion_failed"       // {1} is the coercion error
+	MsgMinItems            = "min_items"             // {1} is the minimum number of elements
+	MsgMaxItems            = "max_items"             // {1} is the maximum number of elements
+	MsgUnique              = "unique"                // reported at the duplicate element
+	MsgInvalidKey          = "invalid_key"           // {1} is the rejected key
+	MsgMaxEntries          = "max_entries"           // {1} is the maximum number of entries
+	MsgPrecision           = "precision"             // {1} is the maximum number of digits
+	MsgScale               = "scale"                 // {1} is the maximum number of decimal places
+	MsgFileTooLarge        = "file_too_large"        // {1} is the size limit in bytes
+	MsgFileExtension       = "file_extension"        // {1} lists the allowed extensions
+	MsgFileMIMEType        = "file_mime_type"        // {1} lists the allowed types, {2} is the detected type
+)
+
+// Catalog supplies the translations for one locale.
+type Catalog struct {
+	// Locale is the go-playground/locales translator, e.g. ru.New().
+	Locale locales.Translator
+	// Messages maps message keys to templates and overrides existing ones.
+	Messages map[string]string
+	// Validator registers validator tag translations for the locale,
+	// e.g. ru_translations.RegisterDefaultTranslations. May be nil.
+	Validator func(*validator.Validate, ut.Translator) error
+}
+
+var uni = ut.New(en.New(), en.New())
+
+// validatorLocales records the locales whose validator translations are registered.
+var validatorLocales = map[string]bool{}
+
+var enMessages = map[string]string{
+	MsgRequired:            "missing required field '{0}' for {1}",
+	MsgTypeMismatch:        "field '{0}' must be {1}",
+	MsgElementTypeMismatch: "element in '{0}' must be {1}",
+	MsgValidationFailed:    "field '{0}' validation failed: {1}",
+	MsgTimeLayout:          "field '{0}' must be {1} in format {2}",
+	MsgNotAllowed:          "field '{0}' must be one of: {1}",
+	MsgOutOfRange:          "field '{0}' must be between {1} and {2}",
+	MsgUnknownField:        "unknown field '{0}'",
+	MsgCoercionFailed:      "field '{0}' could not be converted: {1}",
+	MsgMinItems:            "field '{0}' must have at least {1} items",
+	MsgMaxItems:            "field '{0}' must have at most {1} items",
+	MsgUnique:              "field '{0}' must not contain duplicate items",
+	MsgInvalidKey:          "field '{0}' does not accept key '{1}'",
+	MsgMaxEntries:          "field '{0}' must have at most {1} entries",
+	MutuallyExclusive:      "fields {0} are mutually exclusive",
+	ExactlyOneOf:           "exactly one of {0} must be provided",
+	AtLeastOneOf:           "at least one of {0} must be provided",
+	AllOrNoneOf:            "provide all or none of {0}",
+	MsgPrecision:           "field '{0}' must have at most {1} digits",
+	MsgScale:               "field '{0}' must have at most {1} decimal places",
+	MsgFileTooLarge:        "file '{0}' must not exceed {1} bytes",
+	MsgFileExtension:       "file '{0}' must have one of the extensions: {1}",
+	MsgFileMIMEType:        "file '{0}' must be one of: {1} (got {2})",
+	"type.string":          "string",
+	"type.integer":         "integer",
+	"type.int64":           "integer",
+	"type.uint":            "non-negative integer",
+	"type.float":           "float",
+	"type.bigdecimal":      "bigdecimal (decimal number or string)",
+	"type.numeric":         "numeric (float or string)",
+	"type.date":            "date string",
+	"type.datetime":        "datetime string",
+	"type.time":            "time string",
+	"type.boolean":         "boolean",
+	"type.json":            "json (object, array, or json string)",
+	"type.valid_json":      "valid JSON",
+	"type.slice":           "array",
+	"type.object":          "object",
+	"type.file":            "file",
+}
+
+var ruMessages = map[string]string{
+	MsgRequired:            "отсутствует обязательное поле '{0}' для {1}",
+	MsgTypeMismatch:        "поле '{0}' должно быть {1}",
+	MsgElementTypeMismatch: "элемент в '{0}' должен быть {1}",
+	MsgValidationFailed:    "поле '{0}' не прошло проверку: {1}",
+	MsgTimeLayout:          "поле '{0}' должно быть {1} в формате {2}",
+	MsgNotAllowed:          "поле '{0}' должно быть одним из: {1}",
+	MsgOutOfRange:          "поле '{0}' должно быть в диапазоне от {1} до {2}",
+	MsgUnknownField:        "неизвестное поле '{0}'",
+	MsgCoercionFailed:      "поле '{0}' не удалось преобразовать: {1}",
+	MsgMinItems:            "поле '{0}' должно содержать не менее {1} элементов",
+	MsgMaxItems:            "поле '{0}' должно содержать не более {1} элементов",
+	MsgUnique:              "поле '{0}' не должно содержать повторяющихся элементов",
+	MsgInvalidKey:          "поле '{0}' не допускает ключ '{1}'",
+	MsgMaxEntries:          "поле '{0}' должно содержать не более {1} записей",
+	MutuallyExclusive:      "поля {0} взаимно исключают друг друга",
+	ExactlyOneOf:           "должно быть указано ровно одно из полей {0}",
+	AtLeastOneOf:           "должно быть указано хотя бы одно из полей {0}",
+	AllOrNoneOf:            "поля {0} должны быть указаны все вместе или ни одного",
+	MsgPrecision:           "поле '{0}' должно содержать не более {1} цифр",
+	MsgScale:               "поле '{0}' должно содержать не более {1} знаков после запятой",
+	MsgFileTooLarge:        "файл '{0}' не должен превышать {1} байт",
+	MsgFileExtension:       "файл '{0}' должен иметь одно из расширений: {1}",
+	MsgFileMIMEType:        "файл '{0}' должен быть одного из типов: {1} (получен {2})",
+	"type.string":          "строкой",
+	"type.integer":         "целым числом",
+	"type.int64":           "целым числом",
+	"type.uint":            "неотрицательным целым числом",
+	"type.float":           "числом с плавающей точкой",
+	"type.bigdecimal":      "десятичным числом (число или строка)",
+	"type.numeric":         "числом (число или строка)",
+	"type.date":            "строкой с датой",
+	"type.datetime":        "строкой с датой и временем",
+	"type.time":            "строкой со временем",
+	"type.boolean":         "логическим значением",
+	"type.json":            "JSON (объект, массив или JSON-строка)",
+	"type.valid_json":      "корректным JSON",
+	"type.slice":           "массивом",
+	"type.object":          "объектом",
+	"type.file":            "файлом",
+}
+
+var deMessages = map[string]string{
+	MsgRequired:            "Pflichtfeld '{0}' fehlt für {1}",
+	MsgTypeMismatch:        "Feld '{0}' muss {1} sein",
+	MsgElementTypeMismatch: "Element in '{0}' muss {1} sein",
+	MsgValidationFailed:    "Validierung von Feld '{0}' fehlgeschlagen: {1}",
+	MsgTimeLayout:          "Feld '{0}' muss {1} im Format {2} sein",
+	MsgNotAllowed:          "Feld '{0}' muss einer dieser Werte sein: {1}",
+	MsgOutOfRange:          "Feld '{0}' muss zwischen {1} und {2} liegen",
+	MsgUnknownField:        "unbekanntes Feld '{0}'",
+	MsgCoercionFailed:      "Feld '{0}' konnte nicht umgewandelt werden: {1}",
+	MsgMinItems:            "Feld '{0}' muss mindestens {1} Elemente haben",
+	MsgMaxItems:            "Feld '{0}' darf höchstens {1} Elemente haben",
+	MsgUnique:              "Feld '{0}' darf keine doppelten Elemente enthalten",
+	MsgInvalidKey:          "Feld '{0}' akzeptiert den Schlüssel '{1}' nicht",
+	MsgMaxEntries:          "Feld '{0}' darf höchstens {1} Einträge haben",
+	MutuallyExclusive:      "Felder {0} schließen sich gegenseitig aus",
+	ExactlyOneOf:           "genau eines der Felder {0} muss angegeben werden",
+	AtLeastOneOf:           "mindestens eines der Felder {0} muss angegeben werden",
+	AllOrNoneOf:            "Felder {0} müssen alle oder gar nicht angegeben werden",
+	MsgPrecision:           "Feld '{0}' darf höchstens {1} Ziffern haben",
+	MsgScale:               "Feld '{0}' darf höchstens {1} Nachkommastellen haben",
+	MsgFileTooLarge:        "Datei '{0}' darf höchstens {1} Bytes groß sein",
+	MsgFileExtension:       "Datei '{0}' muss eine dieser Endungen haben: {1}",
+	MsgFileMIMEType:        "Datei '{0}' muss einer dieser Typen sein: {1} (erkannt: {2})",
+	"type.string":          "ein String",
+	"type.integer":         "eine Ganzzahl",
+	"type.int64":           "eine Ganzzahl",
+	"type.uint":            "eine nicht negative Ganzzahl",
+	"type.float":           "eine Gleitkommazahl",
+	"type.bigdecimal":      "eine Dezimalzahl (String oder Zahl)",
+	"type.numeric":         "numerisch (Zahl oder String)",
+	"type.date":            "ein Datums-String",
+	"type.datetime":        "ein Datums- und Zeit-String",
+	"type.time":            "ein Zeit-String",
+	"type.boolean":         "ein Boolean",
+	"type.json":            "JSON (Objekt, Array oder JSON-String)",
+	"type.valid_json":      "gültiges JSON",
+	"type.slice":           "ein Array",
+	"type.object":          "ein Objekt",
+	"type.file":            "eine Datei",
+}
+
+func init() {
+	for _, c := range []Catalog{
+		{Locale: en.New(), Messages: enMessages, Validator: en_translations.RegisterDefaultTranslations},
+		{Locale: ru.New(), Messages: ruMessages, Validator: ru_translations.RegisterDefaultTranslations},
+		{Locale: de.New(), Messages: deMessages, Validator: de_translations.RegisterDefaultTranslations},
+	} {
+		if err := RegisterCatalog(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCatalog adds or extends the translations for c.Locale. Registering
+// a locale that already exists overrides its messages; the validator
+// translations are only registered the first time. Like validator's own
+// registration functions it should be called during initialization.
+func RegisterCatalog(c Catalog) error {
+	locale := c.Locale.Locale()
+	trans, found := uni.GetTranslator(locale)
+	if !found {
+		if err := uni.AddTranslator(c.Locale, false); err != nil {
+			return err
+		}
+		trans, _ = uni.GetTranslator(locale)
+	}
+	if c.Validator != nil && !validatorLocales[locale] {
+		if err := c.Validator(validate, trans); err != nil {
+			return err
+		}
+		validatorLocales[locale] = true
+	}
+	for key, text := range c.Messages {
+		if err := trans.Add(key, text, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Locale returns a copy of p whose bind calls report error messages in the
+// given locale, e.g. "ru" or "de". Unknown locales fall back to English.
+// Assign the copy for a per-Params locale, or call it inline per request:
+//
+//	input, err := schema.Locale(lang).BindAndValidate(raw, "create")
+func (p *Params) Locale(locale string) *Params {
+	cp := *p
+	cp.locale = locale
+	return &cp
+}
+
+// translator returns the translator for locale, falling back to English.
+func translator(locale string) ut.Translator {
+	trans, _ := uni.GetTranslator(locale)
+	return trans
+}
+
+// message renders key from trans, falling back to the English template.
+func message(trans ut.Translator, key string, args ...string) string {
+	if s, err := trans.T(key, args...); err == nil {
+		return s
+	}
+	if s, err := uni.GetFallback().T(key, args...); err == nil {
+		return s
+	}
+	return key
+}
+
+// typeDesc returns the localized description of a type name, or the name
+// itself when no catalog describes it.
+func typeDesc(trans ut.Translator, name string) string {
+	if s, err := trans.T("type." + name); err == nil {
+		return s
+	}
+	if s, err := uni.GetFallback().T("type." + name); err == nil {
+		return s
+	}
+	return name
+}
+
+// localize renders the message of every error in locale and returns ve as
+// an error, or nil when nothing was collected. An empty locale keeps the
+// validator's untranslated messages.
+func (ve ValidationErrors) localize(locale string) error {
+	if len(ve) == 0 {
+		return nil
+	}
+	trans := translator(locale)
+	for _, e := range ve {
+		args := append([]string(nil), e.args...)
+		switch e.key {
+		case MsgTypeMismatch, MsgElementTypeMismatch, MsgTimeLayout:
+			// Handlers may leave out the type, which then is the rule
+			if len(args) < 2 {
+				args = append(args[:len(args):len(args)], e.Rule)
+			}
+			args[1] = typeDesc(trans, args[1])
+		case MsgValidationFailed:
+			if locale != "" && e.verr != nil && len(args) > 1 {
+				args[1] = strings.TrimSpace(e.verr.Translate(trans))
+			}
+		}
+		e.Message = message(trans, e.key, args...)
+	}
+	return ve
+}
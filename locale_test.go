@@ -0,0 +1,134 @@
+// Package grape provides tests for locale.go functionality.
+//
+// Test Functions:
+// - TestLocaleDefaultMessages: Tests that the default locale keeps the English messages
+// - TestLocaleRussian: Tests Russian grape and validator messages
+// - TestLocaleGerman: Tests German messages for nested fields
+// - TestLocaleUnknownFallsBack: Tests fallback to English for unknown locales
+// - TestLocaleDoesNotMutateParams: Tests that Locale returns a copy
+// - TestRegisterCatalog: Tests registering a custom catalog
+package grape
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/fr"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+)
+
+func localeTestSchema() *Params {
+	schema := NewParams()
+	_ = schema.Requires("name").On("create").String()
+	_ = schema.Optional("age").Integer().Validate("min=18")
+	_ = schema.Optional("active").Boolean()
+	return schema
+}
+
+func TestLocaleDefaultMessages(t *testing.T) {
+	raw := createTestJSON(`{"age": 3, "active": "yes"}`)
+	_, err := localeTestSchema().BindAndValidate(raw, "create")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "missing required field 'name' for create") {
+		t.Errorf("Expected English required message, got %v", msg)
+	}
+	if !strings.Contains(msg, "field 'age' validation failed: Key: ''") {
+		t.Errorf("Expected untranslated validator message, got %v", msg)
+	}
+	if !strings.Contains(msg, "field 'active' must be boolean") {
+		t.Errorf("Expected English type message, got %v", msg)
+	}
+}
+
+func TestLocaleRussian(t *testing.T) {
+	raw := createTestJSON(`{"age": 3, "active": "yes"}`)
+	_, err := localeTestSchema().Locale("ru").BindAndValidate(raw, "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+
+	expected := []string{
+		"отсутствует обязательное поле 'name' для create",
+		"поле 'age' не прошло проверку: должен быть больше или равно 18",
+		"поле 'active' должно быть логическим значением",
+	}
+	for i, msg := range expected {
+		if verrs[i].Message != msg {
+			t.Errorf("Error %d: expected %q, got %q", i, msg, verrs[i].Message)
+		}
+	}
+	if verrs[1].Code != "validation_failed:min" {
+		t.Errorf("Expected code to stay untranslated, got %s", verrs[1].Code)
+	}
+}
+
+func TestLocaleGerman(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("email").String().Validate("email")
+	schema := NewParams()
+	_ = schema.Optional("contact").JSON().WithSchema(sub)
+
+	raw := createTestJSON(`{"contact": {"email": "nope"}}`)
+	_, err := schema.Locale("de").BindAndValidate(raw, "")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	expected := "/contact/email: Validierung von Feld 'email' fehlgeschlagen: muss eine gültige E-Mail-Adresse sein"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestLocaleUnknownFallsBack(t *testing.T) {
+	raw := createTestJSON(`{}`)
+	_, err := localeTestSchema().Locale("xx").BindAndValidate(raw, "create")
+	if err == nil || err.Error() != "missing required field 'name' for create" {
+		t.Errorf("Expected English fallback, got %v", err)
+	}
+}
+
+func TestLocaleDoesNotMutateParams(t *testing.T) {
+	schema := localeTestSchema()
+	_ = schema.Locale("ru")
+
+	_, err := schema.BindAndValidate(createTestJSON(`{}`), "create")
+	if err == nil || !strings.HasPrefix(err.Error(), "missing required field") {
+		t.Errorf("Expected original schema to stay English, got %v", err)
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	err := RegisterCatalog(Catalog{
+		Locale: fr.New(),
+		Messages: map[string]string{
+			MsgRequired:     "champ obligatoire '{0}' manquant pour {1}",
+			MsgTypeMismatch: "le champ '{0}' doit être {1}",
+			"type.boolean":  "un booléen",
+		},
+		Validator: fr_translations.RegisterDefaultTranslations,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	raw := createTestJSON(`{"age": 3, "active": "yes"}`)
+	_, err = localeTestSchema().Locale("fr").BindAndValidate(raw, "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	if verrs[0].Message != "champ obligatoire 'name' manquant pour create" {
+		t.Errorf("Expected French required message, got %q", verrs[0].Message)
+	}
+	// Keys missing from the catalog fall back to English templates.
+	if !strings.HasPrefix(verrs[1].Message, "field 'age' validation failed: ") {
+		t.Errorf("Expected English template fallback, got %q", verrs[1].Message)
+	}
+	if verrs[2].Message != "le champ 'active' doit être un booléen" {
+		t.Errorf("Expected French type message, got %q", verrs[2].Message)
+	}
+}
@@ -0,0 +1,164 @@
+package grape
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// KeyRule restricts the keys of a Map field. The zero KeyRule accepts any
+// key.
+type KeyRule struct {
+	Pattern *regexp.Regexp // keys must match; nil accepts any key
+	In      []string       // allowed keys; empty accepts any key
+}
+
+// KeyPattern accepts keys matching the regular expression expr, e.g.
+// `^[a-z][a-z0-9_]*$`. An invalid expression panics.
+func KeyPattern(expr string) KeyRule {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		panic(fmt.Sprintf("grape: invalid key pattern '%s': %v", expr, err))
+	}
+	return KeyRule{Pattern: re}
+}
+
+// KeyIn accepts only the given keys, e.g. currency codes.
+func KeyIn(keys ...string) KeyRule {
+	return KeyRule{In: keys}
+}
+
+// allows reports whether key satisfies the rule.
+func (r KeyRule) allows(key string) bool {
+	if r.Pattern != nil && !r.Pattern.MatchString(key) {
+		return false
+	}
+	return len(r.In) == 0 || containsString(r.In, key)
+}
+
+// MapOf binds an object whose keys satisfy keys and whose values bind as
+// t, or against schema when t is JSON:
+//
+//	_ = p.Optional("labels").MapOf(grape.KeyPattern(`^[a-z]+$`), grape.String, nil)
+//	_ = p.Optional("prices").MapOf(grape.KeyIn("USD", "EUR"), grape.BigDecimal, nil)
+//
+// ElemValidate applies to every value.
+func (f *FieldBuilder) MapOf(keys KeyRule, t FieldType, schema *Params) *FieldBuilder {
+	if t != "" {
+		checkType(t, f.param.Name)
+	}
+	f.param.Type = Map
+	f.param.Keys = keys
+	f.param.ValueType = t
+	f.param.Schema = schema
+	f.updateParent()
+	return f
+}
+
+// MaxEntries limits a map to n entries.
+func (f *FieldBuilder) MaxEntries(n int) *FieldBuilder {
+	f.param.MaxEntries = &n
+	f.updateParent()
+	return f
+}
+
+func (i Input) Map(name string) map[string]interface{} {
+	if v, ok := i[name].(map[string]interface{}); ok {
+		return v
+	}
+	return nil
+}
+
+// valueParam returns the field each value of the map f is bound as.
+func (f Param) valueParam() Param {
+	vp := f.elemParam()
+	vp.Type = f.ValueType
+	return vp
+}
+
+// mapType accepts objects, checks every key against the field's KeyRule
+// and binds every value as its ValueType, reporting failures at the key.
+type mapType struct{}
+
+func (mapType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		b.Mismatch(val, "object")
+		return nil, false
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	vp := b.valueParam()
+	out := make(map[string]interface{}, len(m))
+	failed := false
+	for _, k := range keys {
+		vb := &Binding{Param: vp, Path: pointer(b.Path, k), Mode: b.Mode, params: b.params, errs: b.errs, inList: true}
+		if !b.Keys.allows(k) {
+			vb.Fail(CodeValidationFailed+":key", "key", k, MsgInvalidKey, k)
+			failed = true
+			continue
+		}
+		v, ok := vb.bindWith(vp.Type, vp.decoded(m[k]))
+		if !ok {
+			failed = true
+			continue
+		}
+		out[k] = v
+	}
+	return out, !failed
+}
+
+func (mapType) Validate(b *Binding, val interface{}) bool {
+	m := val.(map[string]interface{})
+	if !b.ValidateTag(m) {
+		return false
+	}
+	if b.MaxEntries != nil && len(m) > *b.MaxEntries {
+		b.Fail(CodeValidationFailed+":max_entries", "max_entries", len(m), MsgMaxEntries, strconv.Itoa(*b.MaxEntries))
+		return false
+	}
+	return true
+}
+
+func (mapType) Describe(f Param) map[string]interface{} {
+	s := map[string]interface{}{"type": "object"}
+	if f.ValueType != "" {
+		s["additionalProperties"] = describeType(f.valueParam())
+	}
+	names := map[string]interface{}{}
+	if f.Keys.Pattern != nil {
+		names["pattern"] = f.Keys.Pattern.String()
+	}
+	if len(f.Keys.In) > 0 {
+		names["enum"] = append([]string(nil), f.Keys.In...)
+	}
+	if len(names) > 0 {
+		s["propertyNames"] = names
+	}
+	if f.MaxEntries != nil {
+		s["maxProperties"] = *f.MaxEntries
+	}
+	return s
+}
+
+// mapLiteral prepares a Go map default, such as map[string]int, value by
+// value. Other values are returned unchanged.
+func (f Param) mapLiteral(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return v
+	}
+	vp := f.valueParam()
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = vp.literal(iter.Value().Interface())
+	}
+	return m
+}
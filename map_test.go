@@ -0,0 +1,172 @@
+// Package grape provides tests for map.go functionality.
+//
+// Test Functions:
+// - TestMapOfValues: Tests binding every value as the value type
+// - TestMapOfKeys: Tests KeyPattern and KeyIn with per-key errors
+// - TestMapOfSchema: Tests values bound against a nested schema
+// - TestMapOfErrors: Tests paths, codes and messages of value and type errors
+// - TestMapMaxEntries: Tests the entry limit
+// - TestMapDefault: Tests Go map defaults and their definition-time check
+// - TestMapDescribe: Tests the JSON Schema of map fields
+// - TestKeyPatternInvalid: Tests the definition-time pattern check
+package grape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapOfValues(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("labels").MapOf(KeyRule{}, String, nil)
+	_ = schema.Optional("prices").MapOf(KeyRule{}, BigDecimal, nil).ElemValidate("required")
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"labels": {"env": "prod", "team": "core"}, "prices": {"USD": 10.10}}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]interface{}{"env": "prod", "team": "core"}
+	if got := input.Map("labels"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if d, ok := input.Map("prices")["USD"].(Decimal); !ok || d.String() != "10.10" {
+		t.Errorf("Expected decimal 10.10, got %#v", input.Map("prices")["USD"])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"labels": {"env": 1}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/labels/env" || verrs[0].Code != CodeTypeMismatch {
+		t.Errorf("Expected type mismatch at /labels/env, got %v", err)
+	}
+}
+
+func TestMapOfKeys(t *testing.T) {
+	tests := []struct {
+		rule KeyRule
+		body string
+		bad  []string
+	}{
+		{KeyPattern(`^[a-z]+$`), `{"m": {"env": 1, "team": 2}}`, nil},
+		{KeyPattern(`^[a-z]+$`), `{"m": {"env": 1, "Team": 2, "a/b": 3}}`, []string{"/m/Team", "/m/a~1b"}},
+		{KeyIn("USD", "EUR"), `{"m": {"USD": 1}}`, nil},
+		{KeyIn("USD", "EUR"), `{"m": {"GBP": 1, "USD": 1}}`, []string{"/m/GBP"}},
+	}
+	for _, tt := range tests {
+		schema := NewParams()
+		_ = schema.Requires("m").MapOf(tt.rule, Integer, nil)
+
+		_, err := schema.BindAndValidate(createTestJSON(tt.body), "")
+		var paths []string
+		if verrs, ok := err.(ValidationErrors); ok {
+			for _, e := range verrs {
+				if e.Code != CodeValidationFailed+":key" {
+					t.Errorf("%s: unexpected code %s", tt.body, e.Code)
+				}
+				paths = append(paths, e.Path)
+			}
+		}
+		if !reflect.DeepEqual(paths, tt.bad) {
+			t.Errorf("%s: expected errors at %v, got %v", tt.body, tt.bad, paths)
+		}
+	}
+}
+
+func TestMapOfSchema(t *testing.T) {
+	price := NewParams()
+	_ = price.Requires("amount").Integer().Validate("min=0")
+
+	schema := NewParams()
+	_ = schema.Requires("prices").MapOf(KeyIn("USD", "EUR"), JSON, price)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"prices": {"USD": {"amount": 10}}}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	usd, _ := input.Map("prices")["USD"].(map[string]interface{})
+	if usd["amount"] != 10 {
+		t.Errorf("Expected amount 10, got %v", usd["amount"])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"prices": {"USD": {"amount": -1}, "EUR": 5}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 || verrs[0].Path != "/prices/EUR" || verrs[1].Path != "/prices/USD/amount" {
+		t.Errorf("Expected errors at /prices/EUR and /prices/USD/amount, got %v", err)
+	}
+}
+
+func TestMapOfErrors(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("labels").MapOf(KeyIn("env"), String, nil)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"labels": {"env": 1, "team": "core"}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected two errors, got %v", err)
+	}
+	if verrs[0].Message != "element in 'labels' must be string" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+	if verrs[1].Message != "field 'labels' does not accept key 'team'" || verrs[1].Field != "labels" {
+		t.Errorf("Unexpected error %+v", verrs[1])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"labels": ["env"]}`), "")
+	verrs, ok = err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/labels" || verrs[0].Rule != "object" {
+		t.Errorf("Expected object mismatch at /labels, got %v", err)
+	}
+}
+
+func TestMapMaxEntries(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("labels").MapOf(KeyRule{}, String, nil).MaxEntries(1)
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"labels": {"env": "prod"}}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err := schema.BindAndValidate(createTestJSON(`{"labels": {"env": "prod", "team": "core"}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != CodeValidationFailed+":max_entries" {
+		t.Fatalf("Expected max_entries error, got %v", err)
+	}
+	if verrs[0].Message != "field 'labels' must have at most 1 entries" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+}
+
+func TestMapDefault(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("limits").MapOf(KeyRule{}, Integer, nil).Default(map[string]int{"cpu": 2})
+
+	input, err := schema.BindAndValidate(createTestJSON(`{}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(input.Map("limits"), map[string]interface{}{"cpu": 2}) {
+		t.Errorf("Unexpected default %#v", input["limits"])
+	}
+
+	expectDefinitionPanic(t, "key rule", func() {
+		_ = NewParams().Optional("limits").MapOf(KeyIn("cpu"), Integer, nil).Default(map[string]int{"gpu": 1})
+	})
+}
+
+func TestMapDescribe(t *testing.T) {
+	schema := NewParams()
+	f := schema.Optional("labels").MapOf(KeyPattern(`^[a-z]+$`), String, nil).MaxEntries(10)
+
+	want := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+		"propertyNames":        map[string]interface{}{"pattern": `^[a-z]+$`},
+		"maxProperties":        10,
+	}
+	if got := describeType(f.param); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestKeyPatternInvalid(t *testing.T) {
+	expectDefinitionPanic(t, "pattern", func() { KeyPattern("[") })
+}
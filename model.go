@@ -0,0 +1,385 @@
+package grape
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeGoType      = reflect.TypeOf(time.Time{})
+	decimalGoType   = reflect.TypeOf(Decimal{})
+	ratGoType       = reflect.TypeOf(big.Rat{})
+	unmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ModelError reports a value ToModelE could not map into its field.
+type ModelError struct {
+	Path  string       // RFC 6901 pointer of the value in Input, e.g. "/items/0/qty"
+	Field string       // Go path of the destination, e.g. "Items[0].Qty"; empty when there is none
+	Type  reflect.Type // type of the destination, or of the struct lacking it
+	Value interface{}
+	Err   error // parse error, if any
+}
+
+func (e *ModelError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("grape: cannot map %s into %s: %v", e.Path, e.Type, e.Err)
+	}
+	msg := fmt.Sprintf("grape: cannot map %s (%T %v) into %s of type %s", e.Path, e.Value, e.Value, e.Field, e.Type)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ModelError) Unwrap() error { return e.Err }
+
+// ToModelE maps Input data into the struct dst points to, like ToModel,
+// and returns an error instead of panicking or skipping values:
+//
+//   - Keys are matched against the `grape` tag, then the `json` tag, then
+//     the snake_case field name; "-" skips a field. Embedded structs and
+//     struct pointers are flattened; a nil pointer is allocated when one of
+//     its fields has a value.
+//   - Objects map into nested structs, maps and pointers to them, arrays
+//     into slices, element by element.
+//   - Date and time strings map into time.Time; decimals into Decimal,
+//     big.Rat, strings, floats and, when whole, integers. Strings map into
+//     encoding.TextUnmarshaler and sql.Scanner types.
+//   - Numbers that don't fit the field, such as 1.5 or 300 for a uint8,
+//     fail.
+//
+// Explicit nulls are handled as in ToModel. The first value that cannot be
+// mapped is returned as a *ModelError; fields mapped before it keep their
+// new values.
+func (i Input) ToModelE(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("grape: ToModelE needs a non-nil struct pointer, got %T", dst)
+	}
+	return mapStruct(v.Elem(), i, "", "")
+}
+
+// mapStruct maps the values of m into the fields of the struct dst. path
+// and field locate dst for errors.
+func mapStruct(dst reflect.Value, m map[string]interface{}, path, field string) error {
+	t := dst.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		if et, ok := embeddedStruct(sf); ok {
+			if err := mapEmbedded(dst.Field(idx), et, m, path, field); err != nil {
+				return err
+			}
+			continue
+		}
+		key, ok := modelKey(sf)
+		if !ok || !sf.IsExported() {
+			continue
+		}
+		val, ok := m[key]
+		if !ok {
+			continue
+		}
+		name := sf.Name
+		if field != "" {
+			name = field + "." + sf.Name
+		}
+		if err := mapValue(dst.Field(idx), val, pointer(path, key), name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// embeddedStruct returns the struct type of sf when sf is an untagged
+// embedded struct or struct pointer, whose fields are flattened.
+func embeddedStruct(sf reflect.StructField) (reflect.Type, bool) {
+	if !sf.Anonymous || sf.Tag.Get("grape") != "" || sf.Tag.Get("json") != "" {
+		return nil, false
+	}
+	t := sf.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// mapEmbedded maps the values of m into the fields of the embedded struct
+// dst of type t. A nil struct pointer is allocated when m holds a value
+// for one of its fields.
+func mapEmbedded(dst reflect.Value, t reflect.Type, m map[string]interface{}, path, field string) error {
+	if dst.Kind() != reflect.Ptr {
+		return mapStruct(dst, m, path, field)
+	}
+	if dst.IsNil() {
+		found := false
+		for key := range modelFields(t) {
+			if _, found = m[key]; found {
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+		if !dst.CanSet() {
+			return &ModelError{Path: path, Type: dst.Type(), Err: fmt.Errorf("cannot set embedded pointer to unexported struct")}
+		}
+		dst.Set(reflect.New(t))
+	}
+	return mapStruct(dst.Elem(), m, path, field)
+}
+
+// modelKey returns the Input key of the struct field sf, reporting false
+// for fields tagged "-".
+func modelKey(sf reflect.StructField) (string, bool) {
+	for _, tag := range []string{"grape", "json"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return toSnakeCase(sf.Name), true
+}
+
+// mapValue maps val into dst, which the error locates by path and field.
+func mapValue(dst reflect.Value, val interface{}, path, field string) error {
+	fail := func(err error) error {
+		return &ModelError{Path: path, Field: field, Type: dst.Type(), Value: val, Err: err}
+	}
+	if val == nil {
+		setNull(dst)
+		return nil
+	}
+	if n, ok := val.(json.Number); ok {
+		d, err := ParseDecimal(n.String())
+		if err != nil {
+			return fail(err)
+		}
+		val = d
+	}
+	if in, ok := val.(Input); ok {
+		val = map[string]interface{}(in)
+	}
+
+	fv := reflect.ValueOf(val)
+	if fv.Type().AssignableTo(dst.Type()) {
+		dst.Set(fv)
+		return nil
+	}
+
+	switch {
+	case dst.Type() == timeGoType:
+		s, ok := val.(string)
+		if !ok {
+			return fail(nil)
+		}
+		tv, err := parseModelTime(s)
+		if err != nil {
+			return fail(err)
+		}
+		dst.Set(reflect.ValueOf(tv))
+		return nil
+	case dst.Type() == decimalGoType || dst.Type() == ratGoType:
+		d, err := modelDecimal(val)
+		if err != nil {
+			return fail(err)
+		}
+		if dst.Type() == ratGoType {
+			dst.Set(reflect.ValueOf(d.Rat()).Elem())
+		} else {
+			dst.Set(reflect.ValueOf(d))
+		}
+		return nil
+	case dst.Kind() == reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if !dst.IsNil() {
+			elem.Elem().Set(dst.Elem())
+		}
+		if err := mapValue(elem.Elem(), val, path, field); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case dst.CanAddr() && dst.Addr().Type().Implements(scannerType):
+		if err := dst.Addr().Interface().(sql.Scanner).Scan(modelScalar(val)); err != nil {
+			return fail(err)
+		}
+		return nil
+	case dst.CanAddr() && dst.Addr().Type().Implements(unmarshalerType):
+		s, ok := val.(string)
+		if !ok {
+			return fail(nil)
+		}
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return fail(err)
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return fail(nil)
+		}
+		return mapStruct(dst, m, path, field)
+	case reflect.Map:
+		m, ok := val.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return fail(nil)
+		}
+		out := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, v := range m {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := mapValue(elem, v, pointer(path, k), fmt.Sprintf("%s[%q]", field, k)); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(out)
+		return nil
+	case reflect.Slice:
+		arr, ok := val.([]interface{})
+		if !ok {
+			return fail(nil)
+		}
+		out := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+		for idx, v := range arr {
+			if err := mapValue(out.Index(idx), v, pointer(path, strconv.Itoa(idx)), fmt.Sprintf("%s[%d]", field, idx)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+	case reflect.String:
+		switch v := val.(type) {
+		case string:
+			dst.SetString(v)
+		case Decimal:
+			dst.SetString(v.String())
+		default:
+			return fail(nil)
+		}
+		return nil
+	case reflect.Bool:
+		b, ok := val.(bool)
+		if !ok {
+			return fail(nil)
+		}
+		dst.SetBool(b)
+		return nil
+	}
+	if !setNumber(dst, val) {
+		return fail(nil)
+	}
+	return nil
+}
+
+// setNumber sets the numeric dst to the number val. It reports false when
+// val is not a number or does not fit dst exactly.
+func setNumber(dst reflect.Value, val interface{}) bool {
+	var r *big.Rat
+	switch v := val.(type) {
+	case Decimal:
+		r = v.Rat()
+	case float64:
+		if dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64 {
+			if dst.OverflowFloat(v) {
+				return false
+			}
+			dst.SetFloat(v)
+			return true
+		}
+		r = new(big.Rat)
+		if r.SetFloat64(v) == nil {
+			return false
+		}
+	default:
+		n, ok := bigInteger(val)
+		if !ok {
+			return false
+		}
+		r = new(big.Rat).SetInt(n)
+	}
+
+	switch dst.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, _ := r.Float64()
+		if dst.OverflowFloat(f) {
+			return false
+		}
+		dst.SetFloat(f)
+		return true
+	}
+	if !r.IsInt() {
+		return false
+	}
+	n := r.Num()
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return false
+		}
+		dst.SetInt(n.Int64())
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return false
+		}
+		dst.SetUint(n.Uint64())
+		return true
+	}
+	return false
+}
+
+// modelDecimal converts a bound number or decimal string to a Decimal.
+func modelDecimal(val interface{}) (Decimal, error) {
+	switch v := val.(type) {
+	case Decimal:
+		return v, nil
+	case string:
+		return ParseDecimal(v)
+	case float64:
+		return ParseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	if n, ok := bigInteger(val); ok {
+		return ParseDecimal(n.String())
+	}
+	return Decimal{}, fmt.Errorf("not a number")
+}
+
+// modelScalar returns val in a form sql.Scanner implementations accept:
+// decimals become strings and Go integers int64.
+func modelScalar(val interface{}) interface{} {
+	if d, ok := val.(Decimal); ok {
+		return d.String()
+	}
+	if n, ok := bigInteger(val); ok && n.IsInt64() {
+		if _, isFloat := val.(float64); !isFloat {
+			return n.Int64()
+		}
+	}
+	return val
+}
+
+// parseModelTime parses s as a datetime, date or time of day.
+func parseModelTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{time.RFC3339Nano, DateLayout, TimeLayout} {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,202 @@
+// Package grape provides tests for model.go functionality.
+//
+// Test Functions:
+// - TestToModelEBasic: Tests mapping scalars by snake_case name
+// - TestToModelETags: Tests grape and json tag names, "-" and embedded structs
+// - TestToModelENested: Tests nested structs, pointers, slices of structs and maps
+// - TestToModelETimeAndDecimal: Tests date strings into time.Time and decimals into Go types
+// - TestToModelEBindResult: Tests mapping the Input of a bound schema
+// - TestToModelEErrors: Tests the path, field and type of mapping errors
+// - TestToModelEBadDestination: Tests the destination check
+package grape
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToModelEBasic(t *testing.T) {
+	type User struct {
+		FirstName string
+		Age       int
+		Score     float32
+		Active    bool
+		Level     uint8
+	}
+	var u User
+	err := Input{"first_name": "John", "age": 30, "score": 1.5, "active": true, "level": int64(7)}.ToModelE(&u)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := User{FirstName: "John", Age: 30, Score: 1.5, Active: true, Level: 7}
+	if u != want {
+		t.Errorf("Expected %+v, got %+v", want, u)
+	}
+}
+
+func TestToModelETags(t *testing.T) {
+	type Audit struct {
+		CreatedBy string
+	}
+	type User struct {
+		Audit
+		Name   string `grape:"full_name" json:"name"`
+		Email  string `json:"email_address,omitempty"`
+		Secret string `json:"-"`
+	}
+	var u User
+	err := Input{"full_name": "John", "name": "ignored", "email_address": "a@b.c", "secret": "x", "created_by": "admin"}.ToModelE(&u)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := User{Audit: Audit{CreatedBy: "admin"}, Name: "John", Email: "a@b.c"}
+	if u != want {
+		t.Errorf("Expected %+v, got %+v", want, u)
+	}
+}
+
+func TestToModelENested(t *testing.T) {
+	type Line struct {
+		SKU string
+		Qty int
+	}
+	type Address struct {
+		City string
+	}
+	type Order struct {
+		Shipping *Address
+		Billing  Address
+		Lines    []Line
+		Labels   map[string]string
+		Tags     []string
+	}
+	var o Order
+	err := Input{
+		"shipping": map[string]interface{}{"city": "Oslo"},
+		"billing":  Input{"city": "Bergen"},
+		"lines":    []interface{}{map[string]interface{}{"sku": "a", "qty": 2}, map[string]interface{}{"sku": "b", "qty": 1.0}},
+		"labels":   map[string]interface{}{"env": "prod"},
+		"tags":     []interface{}{"x", "y"},
+	}.ToModelE(&o)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := Order{
+		Shipping: &Address{City: "Oslo"},
+		Billing:  Address{City: "Bergen"},
+		Lines:    []Line{{SKU: "a", Qty: 2}, {SKU: "b", Qty: 1}},
+		Labels:   map[string]string{"env": "prod"},
+		Tags:     []string{"x", "y"},
+	}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("Expected %+v, got %+v", want, o)
+	}
+}
+
+func TestToModelETimeAndDecimal(t *testing.T) {
+	type Event struct {
+		Day     time.Time
+		At      *time.Time
+		Price   Decimal
+		Total   big.Rat
+		Amount  string
+		Approx  float64
+		Cents   int64
+		Created time.Time
+	}
+	price, _ := ParseDecimal("10.50")
+	cents, _ := ParseDecimal("1050")
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var e Event
+	err := Input{
+		"day": "2024-03-01", "at": "2024-03-01T10:00:00Z", "price": "10.50", "total": price,
+		"amount": price, "approx": price, "cents": cents, "created": created,
+	}.ToModelE(&e)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !e.Day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) || e.At == nil || e.At.Hour() != 10 || !e.Created.Equal(created) {
+		t.Errorf("Unexpected times %v %v %v", e.Day, e.At, e.Created)
+	}
+	if e.Price.String() != "10.50" || e.Total.RatString() != "21/2" || e.Amount != "10.50" || e.Approx != 10.5 || e.Cents != 1050 {
+		t.Errorf("Unexpected decimals %+v", e)
+	}
+}
+
+func TestToModelEBindResult(t *testing.T) {
+	item := NewParams()
+	_ = item.Requires("sku").String()
+	_ = item.Requires("price").BigDecimal()
+
+	schema := NewParams()
+	_ = schema.Requires("placed_on").Date()
+	_ = schema.Requires("items").SliceOf(JSON, item)
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"placed_on": "2024-03-01", "items": [{"sku": "a", "price": 9.99}]}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	type Item struct {
+		SKU   string
+		Price Decimal
+	}
+	var order struct {
+		PlacedOn time.Time
+		Items    []Item
+	}
+	if err := input.ToModelE(&order); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order.PlacedOn.Day() != 1 || len(order.Items) != 1 || order.Items[0].Price.String() != "9.99" {
+		t.Errorf("Unexpected model %+v", order)
+	}
+}
+
+func TestToModelEErrors(t *testing.T) {
+	type Line struct {
+		Qty uint8
+	}
+	type Order struct {
+		Lines []Line
+		Day   time.Time
+	}
+	tests := []struct {
+		input Input
+		path  string
+		field string
+	}{
+		{Input{"lines": []interface{}{map[string]interface{}{"qty": 1}, map[string]interface{}{"qty": 300}}}, "/lines/1/qty", "Lines[1].Qty"},
+		{Input{"lines": []interface{}{map[string]interface{}{"qty": 1.5}}}, "/lines/0/qty", "Lines[0].Qty"},
+		{Input{"lines": []interface{}{map[string]interface{}{"qty": "1"}}}, "/lines/0/qty", "Lines[0].Qty"},
+		{Input{"lines": "x"}, "/lines", "Lines"},
+		{Input{"day": "tomorrow"}, "/day", "Day"},
+	}
+	for _, tt := range tests {
+		var o Order
+		err := tt.input.ToModelE(&o)
+		var merr *ModelError
+		if !errors.As(err, &merr) {
+			t.Errorf("%v: expected ModelError, got %v", tt.input, err)
+			continue
+		}
+		if merr.Path != tt.path || merr.Field != tt.field {
+			t.Errorf("Expected %s %s, got %s %s", tt.path, tt.field, merr.Path, merr.Field)
+		}
+	}
+}
+
+func TestToModelEBadDestination(t *testing.T) {
+	var s struct{ Name string }
+	var n int
+	for _, dst := range []interface{}{s, nil, &n, (*struct{})(nil)} {
+		if err := (Input{"name": "x"}).ToModelE(dst); err == nil {
+			t.Errorf("%T: expected error", dst)
+		}
+	}
+}
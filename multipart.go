@@ -0,0 +1,177 @@
+package grape
+
+import (
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// DefaultMultipartMemory is the maxMemory passed to ParseMultipartForm by
+// BindMultipart. File parts beyond it are stored on disk.
+var DefaultMultipartMemory int64 = 32 << 20
+
+// BindMultipart parses a multipart/form-data request and binds it. Text
+// parts are coerced like BindQuery; File fields receive a
+// *multipart.FileHeader and SliceOf(File, nil) fields receive every
+// uploaded file for the key.
+func (p *Params) BindMultipart(r *http.Request, mode string) (Input, error) {
+	if err := r.ParseMultipartForm(DefaultMultipartMemory); err != nil {
+		return nil, err
+	}
+	raw := p.coerceValues(r.MultipartForm.Value)
+	for _, f := range p.allFields() {
+		for _, key := range f.keys() {
+			files := r.MultipartForm.File[key]
+			if len(files) == 0 {
+				continue
+			}
+			if f.Type == Slice {
+				arr := make([]interface{}, len(files))
+				for i, fh := range files {
+					arr[i] = fh
+				}
+				raw[key] = arr
+				continue
+			}
+			raw[key] = files[0]
+		}
+	}
+	return p.BindAndValidate(raw, mode)
+}
+
+func (f *FieldBuilder) File() *FieldBuilder { f.param.Type = File; f.updateParent(); return f }
+
+// MaxSize limits the size of uploaded files in bytes.
+func (f *FieldBuilder) MaxSize(bytes int64) *FieldBuilder {
+	f.param.MaxSize = bytes
+	f.updateParent()
+	return f
+}
+
+// Extensions limits uploaded files to the given file name extensions,
+// e.g. ".png" or "jpg". Matching is case-insensitive.
+func (f *FieldBuilder) Extensions(exts ...string) *FieldBuilder {
+	for _, ext := range exts {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		f.param.Extensions = append(f.param.Extensions, ext)
+	}
+	f.updateParent()
+	return f
+}
+
+// MIMETypes limits uploaded files to the given MIME types, detected from
+// the file content rather than the client-supplied header. A "type/*"
+// entry accepts any subtype.
+func (f *FieldBuilder) MIMETypes(types ...string) *FieldBuilder {
+	f.param.MIMETypes = append(f.param.MIMETypes, types...)
+	f.updateParent()
+	return f
+}
+
+func (i Input) File(name string) *multipart.FileHeader {
+	if v, ok := i[name].(*multipart.FileHeader); ok {
+		return v
+	}
+	return nil
+}
+
+func (i Input) Files(name string) []*multipart.FileHeader {
+	vals, _ := i[name].([]interface{})
+	files := make([]*multipart.FileHeader, 0, len(vals))
+	for _, v := range vals {
+		if fh, ok := v.(*multipart.FileHeader); ok {
+			files = append(files, fh)
+		}
+	}
+	return files
+}
+
+// fileType accepts uploaded files and applies the size, extension and MIME
+// type rules.
+type fileType struct{}
+
+func (fileType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	fh, ok := val.(*multipart.FileHeader)
+	if !ok {
+		b.Mismatch(val, string(File))
+	}
+	return fh, ok
+}
+
+func (fileType) Validate(b *Binding, val interface{}) bool {
+	return b.errs.checkFile(b.Param, b.Path, val.(*multipart.FileHeader))
+}
+
+func (fileType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "format": "binary"}
+}
+
+// checkFile applies f's size, extension and MIME type rules to fh and
+// records failures at path. It reports whether fh passed.
+func (ve *ValidationErrors) checkFile(f Param, path string, fh *multipart.FileHeader) bool {
+	if f.MaxSize > 0 && fh.Size > f.MaxSize {
+		ve.add(CodeValidationFailed+":max_size", f.Name, path, "max_size", fh.Filename,
+			MsgFileTooLarge, f.Name, strconv.FormatInt(f.MaxSize, 10))
+		return false
+	}
+	if len(f.Extensions) > 0 {
+		ext := strings.ToLower(filepath.Ext(fh.Filename))
+		if !containsString(f.Extensions, ext) {
+			ve.add(CodeValidationFailed+":extension", f.Name, path, "extension", fh.Filename,
+				MsgFileExtension, f.Name, strings.Join(f.Extensions, ", "))
+			return false
+		}
+	}
+	if len(f.MIMETypes) > 0 {
+		file, err := fh.Open()
+		if err != nil {
+			ve.add(CodeValidationFailed+":mime_type", f.Name, path, "mime_type", fh.Filename,
+				MsgFileMIMEType, f.Name, strings.Join(f.MIMETypes, ", "), "unreadable")
+			return false
+		}
+		detected, err := mimetype.DetectReader(file)
+		file.Close()
+		if err != nil || !mimeAllowed(detected, f.MIMETypes) {
+			got := "unknown"
+			if detected != nil {
+				got = detected.String()
+			}
+			ve.add(CodeValidationFailed+":mime_type", f.Name, path, "mime_type", fh.Filename,
+				MsgFileMIMEType, f.Name, strings.Join(f.MIMETypes, ", "), got)
+			return false
+		}
+	}
+	return true
+}
+
+// mimeAllowed reports whether detected matches one of the allowed types.
+func mimeAllowed(detected *mimetype.MIME, allowed []string) bool {
+	for _, a := range allowed {
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok {
+			if strings.HasPrefix(detected.String(), prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if detected.Is(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+// Package grape provides tests for multipart.go functionality.
+//
+// Test Functions:
+// - TestFieldBuilderFile: Tests File type and file rule setup
+// - TestBindMultipartSuccess: Tests binding of files and coerced text parts
+// - TestBindMultipartMaxSize: Tests the size limit
+// - TestBindMultipartExtensions: Tests the extension allow list
+// - TestBindMultipartMIMETypes: Tests MIME type detection from file content
+// - TestBindMultipartSliceOfFiles: Tests multiple files for one key with per-index errors
+// - TestBindMultipartMissingFile: Tests required file fields
+// - TestBindAndValidateFileFromJSON: Tests that JSON values are rejected for File fields
+package grape
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var pngBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89")
+
+type testUpload struct {
+	field, filename string
+	content         []byte
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files ...testUpload) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range files {
+		part, err := w.CreateFormFile(f.field, f.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(f.content)
+	}
+	w.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestFieldBuilderFile(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("avatar").File().MaxSize(1024).Extensions("PNG", ".jpg").MIMETypes("image/png")
+
+	field := schema.Fields[0]
+	if field.Type != File {
+		t.Errorf("Expected File type, got %v", field.Type)
+	}
+	if field.MaxSize != 1024 {
+		t.Errorf("Expected MaxSize 1024, got %d", field.MaxSize)
+	}
+	if len(field.Extensions) != 2 || field.Extensions[0] != ".png" || field.Extensions[1] != ".jpg" {
+		t.Errorf("Expected normalized extensions [.png .jpg], got %v", field.Extensions)
+	}
+	if len(field.MIMETypes) != 1 || field.MIMETypes[0] != "image/png" {
+		t.Errorf("Expected MIME types [image/png], got %v", field.MIMETypes)
+	}
+}
+
+func TestBindMultipartSuccess(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("avatar").On("create").File().MaxSize(1024).Extensions("png").MIMETypes("image/*")
+	_ = schema.Requires("name").On("create").String()
+	_ = schema.Optional("public").Boolean()
+
+	r := newMultipartRequest(t, map[string]string{"name": "John", "public": "1"},
+		testUpload{"avatar", "me.PNG", pngBytes})
+	input, err := schema.BindMultipart(r, "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	fh := input.File("avatar")
+	if fh == nil || fh.Filename != "me.PNG" {
+		t.Fatalf("Expected avatar file header, got %v", input["avatar"])
+	}
+	if input.String("name") != "John" {
+		t.Errorf("Expected name 'John', got %v", input["name"])
+	}
+	if input.Boolean("public", false) != true {
+		t.Errorf("Expected public true, got %v", input["public"])
+	}
+}
+
+func TestBindMultipartMaxSize(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("doc").File().MaxSize(4)
+
+	r := newMultipartRequest(t, nil, testUpload{"doc", "a.txt", []byte("too long")})
+	_, err := schema.BindMultipart(r, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 {
+		t.Fatalf("Expected one error, got %v", err)
+	}
+	if verrs[0].Code != "validation_failed:max_size" {
+		t.Errorf("Expected max_size code, got %s", verrs[0].Code)
+	}
+	if verrs[0].Message != "file 'doc' must not exceed 4 bytes" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+}
+
+func TestBindMultipartExtensions(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("doc").File().Extensions("pdf", "docx")
+
+	r := newMultipartRequest(t, nil, testUpload{"doc", "a.exe", []byte("MZ")})
+	_, err := schema.BindMultipart(r, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != "validation_failed:extension" {
+		t.Fatalf("Expected extension error, got %v", err)
+	}
+	if verrs[0].Value != "a.exe" {
+		t.Errorf("Expected rejected filename, got %v", verrs[0].Value)
+	}
+}
+
+func TestBindMultipartMIMETypes(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("avatar").File().MIMETypes("image/png", "image/jpeg")
+
+	// The extension claims PNG, the content is plain text.
+	r := newMultipartRequest(t, nil, testUpload{"avatar", "fake.png", []byte("hello world")})
+	_, err := schema.BindMultipart(r, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != "validation_failed:mime_type" {
+		t.Fatalf("Expected mime_type error, got %v", err)
+	}
+	expected := "file 'avatar' must be one of: image/png, image/jpeg (got text/plain; charset=utf-8)"
+	if verrs[0].Message != expected {
+		t.Errorf("Expected %q, got %q", expected, verrs[0].Message)
+	}
+
+	r = newMultipartRequest(t, nil, testUpload{"avatar", "real.bin", pngBytes})
+	if _, err := schema.BindMultipart(r, ""); err != nil {
+		t.Errorf("Expected PNG content to pass, got %v", err)
+	}
+}
+
+func TestBindMultipartSliceOfFiles(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("docs").SliceOf(File, nil).Extensions("txt")
+
+	r := newMultipartRequest(t, nil,
+		testUpload{"docs", "a.txt", []byte("a")},
+		testUpload{"docs", "b.txt", []byte("b")})
+	input, err := schema.BindMultipart(r, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	files := input.Files("docs")
+	if len(files) != 2 || files[1].Filename != "b.txt" {
+		t.Errorf("Expected two files, got %v", files)
+	}
+
+	r = newMultipartRequest(t, nil,
+		testUpload{"docs", "a.txt", []byte("a")},
+		testUpload{"docs", "b.csv", []byte("b")})
+	_, err = schema.BindMultipart(r, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/docs/1" {
+		t.Errorf("Expected error at /docs/1, got %v", err)
+	}
+}
+
+func TestBindMultipartMissingFile(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("avatar").On("create").File()
+
+	r := newMultipartRequest(t, map[string]string{"name": "John"})
+	_, err := schema.BindMultipart(r, "create")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Code != CodeRequired {
+		t.Errorf("Expected required error, got %v", err)
+	}
+}
+
+func TestBindAndValidateFileFromJSON(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("avatar").File()
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"avatar": "me.png"}`), "")
+	if err == nil || err.Error() != "field 'avatar' must be file" {
+		t.Errorf("Expected file type error, got %v", err)
+	}
+}
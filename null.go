@@ -0,0 +1,69 @@
+package grape
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// Nullable accepts an explicit JSON null for the field, e.g. to clear a
+// value in a PATCH request. The null is stored in Input as nil: Has
+// reports the key, IsNull the null. Without Nullable a null is a type
+// mismatch.
+func (f *FieldBuilder) Nullable() *FieldBuilder {
+	f.param.Nullable = true
+	f.updateParent()
+	return f
+}
+
+// Has reports whether name was submitted, including as an explicit null,
+// or set by a default.
+func (i Input) Has(name string) bool {
+	_, ok := i[name]
+	return ok
+}
+
+// IsNull reports whether name was submitted as an explicit null.
+func (i Input) IsNull(name string) bool {
+	v, ok := i[name]
+	return ok && v == nil
+}
+
+// setNull clears field for an explicit null. Pointer, slice, map and
+// interface fields become nil and sql.Scanner fields such as
+// sql.NullString scan nil; other fields are left unchanged and setNull
+// reports false.
+func setNull(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		field.Set(reflect.Zero(field.Type()))
+		return true
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(scannerType) {
+		return field.Addr().Interface().(sql.Scanner).Scan(nil) == nil
+	}
+	return false
+}
+
+// setValue sets field to val when val's type fits it, also through a
+// pointer or, for sql.Null types, sql.Scanner. It reports whether the
+// field was set.
+func setValue(field reflect.Value, val interface{}) bool {
+	fv := reflect.ValueOf(val)
+	switch {
+	case fv.Type().AssignableTo(field.Type()):
+		field.Set(fv)
+	case fv.Type().ConvertibleTo(field.Type()):
+		field.Set(fv.Convert(field.Type()))
+	case field.Kind() == reflect.Ptr && fv.Type().ConvertibleTo(field.Type().Elem()):
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(fv.Convert(field.Type().Elem()))
+		field.Set(ptr)
+	case field.CanAddr() && field.Addr().Type().Implements(scannerType):
+		return field.Addr().Interface().(sql.Scanner).Scan(val) == nil
+	default:
+		return false
+	}
+	return true
+}
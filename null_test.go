@@ -0,0 +1,109 @@
+// Package grape provides tests for null.go functionality.
+//
+// Test Functions:
+// - TestNullableBind: Tests explicit nulls of Nullable and other fields
+// - TestNullableNested: Tests Nullable fields of nested schemas
+// - TestInputHasIsNull: Tests telling absent keys, nulls and values apart
+// - TestToModelNull: Tests clearing pointer, slice and sql.Null fields on explicit null
+// - TestToModelPointerAndScanner: Tests setting values through pointers and sql.Scanner
+package grape
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestNullableBind(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("nickname").String().Nullable()
+	_ = schema.Requires("bio").String().Nullable()
+	_ = schema.Optional("name").String()
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"nickname": null, "bio": null}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !input.IsNull("nickname") || !input.IsNull("bio") {
+		t.Errorf("Expected explicit nulls, got %v", input)
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"bio": "x", "name": null}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Field != "name" || verrs[0].Code != CodeTypeMismatch {
+		t.Errorf("Expected type mismatch for name, got %v", err)
+	}
+}
+
+func TestNullableNested(t *testing.T) {
+	address := NewParams()
+	_ = address.Optional("line2").String().Nullable()
+
+	schema := NewParams()
+	_ = schema.Optional("address").JSON().WithSchema(address)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"address": {"line2": null}}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nested, _ := input["address"].(map[string]interface{})
+	if v, ok := nested["line2"]; !ok || v != nil {
+		t.Errorf("Expected explicit null, got %v", nested)
+	}
+}
+
+func TestInputHasIsNull(t *testing.T) {
+	input := Input{"name": "John", "nickname": nil}
+
+	tests := []struct {
+		name   string
+		has    bool
+		isNull bool
+	}{
+		{"name", true, false},
+		{"nickname", true, true},
+		{"missing", false, false},
+	}
+	for _, tt := range tests {
+		if input.Has(tt.name) != tt.has || input.IsNull(tt.name) != tt.isNull {
+			t.Errorf("%s: expected Has=%v IsNull=%v", tt.name, tt.has, tt.isNull)
+		}
+	}
+}
+
+func TestToModelNull(t *testing.T) {
+	type Profile struct {
+		Name     string
+		Nickname *string
+		Tags     []string
+		Bio      sql.NullString
+	}
+	nick := "johnny"
+	p := Profile{Name: "John", Nickname: &nick, Tags: []string{"a"}, Bio: sql.NullString{String: "hi", Valid: true}}
+
+	Input{"name": nil, "nickname": nil, "tags": nil, "bio": nil}.ToModel(&p)
+
+	if p.Name != "John" {
+		t.Errorf("Expected Name to be kept, got %q", p.Name)
+	}
+	if p.Nickname != nil || p.Tags != nil || p.Bio.Valid {
+		t.Errorf("Expected cleared fields, got %+v", p)
+	}
+}
+
+func TestToModelPointerAndScanner(t *testing.T) {
+	type Profile struct {
+		Nickname *string
+		Bio      sql.NullString
+		Age      sql.NullInt64
+	}
+	var p Profile
+
+	Input{"nickname": "johnny", "bio": "hi", "age": 30}.ToModel(&p)
+
+	if p.Nickname == nil || *p.Nickname != "johnny" {
+		t.Errorf("Expected Nickname johnny, got %v", p.Nickname)
+	}
+	if !p.Bio.Valid || p.Bio.String != "hi" || !p.Age.Valid || p.Age.Int64 != 30 {
+		t.Errorf("Expected scanned values, got %+v", p)
+	}
+}
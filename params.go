@@ -5,18 +5,49 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
 var validate = validator.New()
 
+var regexCache sync.Map // pattern -> *regexp.Regexp
+
+// The regex validator tag matches strings against its parameter, e.g.
+// "regex=^[a-z]+$". As in every validator parameter, commas and pipes are
+// written as 0x2C and 0x7C. Invalid patterns panic when the tag is set;
+// one that reaches the validator anyway matches nothing.
+func init() {
+	_ = validate.RegisterValidation("regex", func(fl validator.FieldLevel) bool {
+		re, err := compileRegex(fl.Param())
+		return err == nil && re.MatchString(fl.Field().String())
+	})
+}
+
+// compileRegex compiles the pattern of a regex tag once.
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := regexCache.LoadOrStore(expr, re)
+	return cached.(*regexp.Regexp), nil
+}
+
 type FieldType string
 
 const (
 	String    FieldType = "string"
 	Integer   FieldType = "integer"
+	Int64     FieldType = "int64"
+	Uint      FieldType = "uint"
 	Float     FieldType = "float"
 	BigDecimal FieldType = "bigdecimal"
 	Numeric   FieldType = "numeric"
@@ -26,6 +57,8 @@ const (
 	Boolean   FieldType = "boolean"
 	JSON      FieldType = "json"
 	Slice     FieldType = "slice"
+	Map       FieldType = "map"
+	File      FieldType = "file"
 )
 
 type Param struct {
@@ -35,10 +68,45 @@ type Param struct {
 	RequiredOn []string
 	Schema     *Params
 	SliceType  FieldType
+	MaxSize    int64    // File: maximum size in bytes
+	Extensions []string // File: allowed file name extensions
+	MIMETypes  []string // File: allowed detected MIME types
+
+	Default     interface{}             // value used when the field is absent
+	DefaultFunc func(Input) interface{} // computes the value used when the field is absent
+	DefaultOn   []string                // modes the default applies to; empty means all
+
+	Values []interface{} // allowed values for String, Integer, Int64, Uint and Float fields
+
+	Precision int  // BigDecimal: maximum number of digits; 0 means unlimited
+	Scale     *int // BigDecimal: maximum number of decimal places; nil means unlimited
+
+	Layouts  []string       // Date, DateTime, Time: accepted layouts; empty uses the type default
+	Location *time.Location // Date, DateTime, Time: zone of the bound value; nil means UTC
+
+	Coerce func(interface{}) (interface{}, error) // converts submitted values before binding
+
+	External string   // name clients submit the field under; empty means Name
+	Aliases  []string // further names accepted for backward compatibility
+
+	ElemValidate string // Slice: validator tag applied to every element
+	MinItems     int    // Slice: minimum number of elements
+	MaxItems     *int   // Slice: maximum number of elements; nil means unlimited
+	Unique       bool   // Slice: reject equal elements
+
+	ValueType  FieldType // Map: type of the values
+	Keys       KeyRule   // Map: rule every key must satisfy
+	MaxEntries *int      // Map: maximum number of entries; nil means unlimited
+
+	Nullable bool // accept an explicit null, stored in Input as nil
 }
 
 type Params struct {
-	Fields []Param
+	Fields     []Param
+	Groups     []Group
+	Conditions []Condition
+	locale     string
+	unknown    UnknownKeyPolicy
 }
 
 type FieldBuilder struct {
@@ -49,17 +117,22 @@ type FieldBuilder struct {
 func NewParams() *Params { return &Params{Fields: []Param{}} }
 
 func (p *Params) Requires(name string) *FieldBuilder {
+	p.claim(name, name)
 	fb := &FieldBuilder{param: Param{Name: name, RequiredOn: []string{}}, parent: p}
 	p.Fields = append(p.Fields, fb.param)
 	return fb
 }
 
 func (p *Params) Optional(name string) *FieldBuilder {
+	p.claim(name, name)
 	fb := &FieldBuilder{param: Param{Name: name}, parent: p}
 	p.Fields = append(p.Fields, fb.param)
 	return fb
 }
 
+// AllModes passed to On makes a field required in every mode.
+const AllModes = "*"
+
 func (f *FieldBuilder) On(endpoints ...string) *FieldBuilder {
 	f.param.RequiredOn = append(f.param.RequiredOn, endpoints...)
 	f.updateParent()
@@ -87,6 +160,9 @@ func (f *FieldBuilder) WithSchema(s *Params) *FieldBuilder {
 	return f
 }
 func (f *FieldBuilder) SliceOf(t FieldType, s *Params) *FieldBuilder {
+	if t != "" {
+		checkType(t, f.param.Name)
+	}
 	f.param.Type = Slice
 	f.param.SliceType = t
 	f.param.Schema = s
@@ -94,7 +170,43 @@ func (f *FieldBuilder) SliceOf(t FieldType, s *Params) *FieldBuilder {
 	return f
 }
 
+// Default sets the value used when the field is absent, optionally only for
+// the given modes. The value is checked against the field type and
+// validator tag as the schema is built; an incompatible default panics.
+func (f *FieldBuilder) Default(v interface{}, modes ...string) *FieldBuilder {
+	f.param.Default = v
+	f.param.DefaultFunc = nil
+	f.param.DefaultOn = modes
+	f.updateParent()
+	return f
+}
+
+// DefaultFunc sets a function computing the value used when the field is
+// absent, optionally only for the given modes. fn receives the fields
+// declared before this one; its result is bound like a submitted value.
+func (f *FieldBuilder) DefaultFunc(fn func(Input) interface{}, modes ...string) *FieldBuilder {
+	f.param.Default = nil
+	f.param.DefaultFunc = fn
+	f.param.DefaultOn = modes
+	f.updateParent()
+	return f
+}
+
+// Values restricts a String, Integer, Int64, Uint or Float field to the
+// given values. The values are checked against the field type as the
+// schema is built and stay readable from Param.Values for documentation
+// and exporters.
+func (f *FieldBuilder) Values(values ...interface{}) *FieldBuilder {
+	f.param.Values = values
+	f.updateParent()
+	return f
+}
+
 func (f *FieldBuilder) updateParent() {
+	f.param.checkRegex()
+	f.param.checkTag()
+	f.param.checkValues()
+	f.param.checkDefault()
 	for i := range f.parent.Fields {
 		if f.parent.Fields[i].Name == f.param.Name {
 			f.parent.Fields[i] = f.param
@@ -130,7 +242,10 @@ func (i Input) Boolean(name string, def bool) bool {
 	return def
 }
 func (i Input) BigDecimal(name string) string {
-	if v, ok := i[name].(string); ok {
+	switch v := i[name].(type) {
+	case Decimal:
+		return v.String()
+	case string:
 		return v
 	}
 	return ""
@@ -147,24 +262,9 @@ func (i Input) Numeric(name string, def float64) float64 {
 	}
 	return def
 }
-func (i Input) Date(name string) string {
-	if v, ok := i[name].(string); ok {
-		return v
-	}
-	return ""
-}
-func (i Input) DateTime(name string) string {
-	if v, ok := i[name].(string); ok {
-		return v
-	}
-	return ""
-}
-func (i Input) Time(name string) string {
-	if v, ok := i[name].(string); ok {
-		return v
-	}
-	return ""
-}
+func (i Input) Date(name string) string     { return i.timeString(name, DateLayout) }
+func (i Input) DateTime(name string) string { return i.timeString(name, DateTimeLayout) }
+func (i Input) Time(name string) string     { return i.timeString(name, TimeLayout) }
 func (i Input) JSON(name string) interface{} {
 	return i[name]
 }
@@ -177,7 +277,8 @@ func parseFloat(s string) (float64, error) {
 }
 
 // ToModel maps Input data to a struct pointer, converting snake_case keys to PascalCase fields.
-// It only sets non-nil values to avoid overwriting existing data.
+// Explicit nulls of Nullable fields clear pointer, slice, map and sql.Null fields such as
+// sql.NullString; other fields keep their data.
 func (i Input) ToModel(dst interface{}) {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr || v.IsNil() {
@@ -194,15 +295,10 @@ func (i Input) ToModel(dst interface{}) {
 		}
 
 		if val == nil {
-			continue // don't overwrite with nil
-		}
-
-		fv := reflect.ValueOf(val)
-		if fv.Type().AssignableTo(field.Type()) {
-			field.Set(fv)
-		} else if fv.Type().ConvertibleTo(field.Type()) {
-			field.Set(fv.Convert(field.Type()))
+			setNull(field) // other fields can't hold nil and keep their data
+			continue
 		}
+		setValue(field, val)
 	}
 }
 
@@ -233,208 +329,214 @@ func toSnakeCase(str string) string {
 	return string(out)
 }
 
+// BindAndValidate binds raw against the schema for the given mode. Every
+// failing field is reported; the returned error is a ValidationErrors.
 func (p *Params) BindAndValidate(raw map[string]interface{}, mode string) (Input, error) {
+	out, errs := p.bindObject(raw, mode)
+	if err := errs.localize(p.locale); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// bindObject binds raw as an object of p. It is used at every depth:
+// nested schemas bind through the field types that hold them. The errors
+// are returned unlocalized.
+func (p *Params) bindObject(raw map[string]interface{}, mode string) (Input, ValidationErrors) {
 	out := Input{}
+	var errs ValidationErrors
+	p.bindFields(raw, mode, out, &errs)
+	p.bindUnknown(raw, out, &errs)
+	return out, errs
+}
 
+// bindFields binds the declared fields of p, the blocks whose condition
+// holds and p's group rules into out.
+func (p *Params) bindFields(raw map[string]interface{}, mode string, out Input, errs *ValidationErrors) {
 	for _, f := range p.Fields {
-		val, ok := raw[f.Name]
+		// Input uses the declared name; lookups and errors the external one
+		name := f.Name
+		val, ok := f.lookup(raw)
+		f = f.external()
+		path := pointer("", f.Name)
+
+		isRequired := f.requiredIn(mode)
 
-		isRequired := false
-		for _, r := range f.RequiredOn {
-			if strings.TrimSpace(r) == mode {
-				isRequired = true
-				break
+		if ok && val == nil && f.Nullable {
+			out[name] = nil
+			continue
+		}
+		if ok {
+			if val, ok = errs.coerce(f, path, val); !ok {
+				continue
 			}
+		} else {
+			val, ok = f.defaultFor(mode, out)
 		}
-
 		if !ok {
 			if isRequired {
-				return nil, fmt.Errorf("missing required field '%s' for %s", f.Name, mode)
+				errs.required(f.Name, path, mode)
 			}
 			continue
 		}
 
-		switch f.Type {
-		case String:
-			s, ok := val.(string)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be string", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(s, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = s
-		case Integer:
-			switch vv := val.(type) {
-			case float64:
-				i := int(vv)
-				if f.Validate != "" {
-					if err := validate.Var(i, f.Validate); err != nil {
-						return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-					}
-				}
-				out[f.Name] = i
-			case int:
-				out[f.Name] = vv
-			default:
-				return nil, fmt.Errorf("field '%s' must be integer", f.Name)
-			}
-		case Float:
-			fv, ok := val.(float64)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be float", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(fv, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = fv
-		case BigDecimal:
-			// BigDecimal can be a string representation of a decimal number
-			switch vv := val.(type) {
-			case string:
-				if f.Validate != "" {
-					if err := validate.Var(vv, f.Validate); err != nil {
-						return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-					}
-				}
-				out[f.Name] = vv
-			case float64:
-				s := fmt.Sprintf("%.10f", vv)
-				out[f.Name] = s
-			default:
-				return nil, fmt.Errorf("field '%s' must be bigdecimal (string or float)", f.Name)
-			}
-		case Numeric:
-			// Numeric is similar to Float but accepts both float and string
-			switch vv := val.(type) {
-			case float64:
-				if f.Validate != "" {
-					if err := validate.Var(vv, f.Validate); err != nil {
-						return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-					}
-				}
-				out[f.Name] = vv
-			case string:
-				if f.Validate != "" {
-					if err := validate.Var(vv, f.Validate); err != nil {
-						return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-					}
-				}
-				out[f.Name] = vv
-			default:
-				return nil, fmt.Errorf("field '%s' must be numeric (float or string)", f.Name)
-			}
-		case Date:
-			// Date expects a string in date format
-			s, ok := val.(string)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be date string", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(s, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = s
-		case DateTime:
-			// DateTime expects a string in datetime format
-			s, ok := val.(string)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be datetime string", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(s, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = s
-		case Time:
-			// Time expects a string in time format
-			s, ok := val.(string)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be time string", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(s, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = s
-		case Boolean:
-			bv, ok := val.(bool)
+		if v, ok := p.bindValue(f, val, path, mode, errs); ok {
+			out[name] = v
+		}
+	}
+
+	for _, block := range p.activeBlocks(out) {
+		block.bindFields(raw, mode, out, errs)
+	}
+	p.checkGroups(raw, mode, errs)
+}
+
+// bindValue checks val against f's type and rules and returns the bound
+// value. Failures are recorded in errs at path.
+func (p *Params) bindValue(f Param, val interface{}, path, mode string, errs *ValidationErrors) (interface{}, bool) {
+	b := &Binding{Param: f, Path: path, Mode: mode, params: p, errs: errs}
+	// Only BigDecimal and integer fields need the exact text of numbers
+	// from BindAndValidateReader
+	return b.bindWith(f.Type, f.decoded(val))
+}
+
+// defaultFor returns the default value of f for mode, if it has one.
+// Numbers are prepared with literal so the default binds like decoded JSON.
+func (f Param) defaultFor(mode string, bound Input) (interface{}, bool) {
+	if f.Default == nil && f.DefaultFunc == nil {
+		return nil, false
+	}
+	if len(f.DefaultOn) > 0 && !containsString(f.DefaultOn, mode) {
+		return nil, false
+	}
+	val := f.Default
+	if f.DefaultFunc != nil {
+		val = f.DefaultFunc(bound)
+	}
+	return f.literal(val), true
+}
+
+// checkDefault panics when the static default does not bind as f.
+func (f Param) checkDefault() {
+	if f.Default == nil || f.Type == "" {
+		return
+	}
+	var errs ValidationErrors
+	if _, ok := (&Params{}).bindValue(f, f.literal(f.Default), pointer("", f.Name), "", &errs); !ok {
+		errs.localize("")
+		panic(fmt.Sprintf("grape: invalid default for field '%s': %v", f.Name, errs))
+	}
+}
+
+// checkRegex panics when a regex rule of the validator tags of f has an
+// invalid pattern.
+func (f Param) checkRegex() {
+	for _, tag := range []string{f.Validate, f.ElemValidate} {
+		for _, rule := range strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == '|' }) {
+			expr, ok := strings.CutPrefix(strings.TrimSpace(rule), "regex=")
 			if !ok {
-				return nil, fmt.Errorf("field '%s' must be boolean", f.Name)
+				continue
 			}
-			out[f.Name] = bv
-		case JSON:
-			// JSON can be a map, slice, or string containing JSON
-			switch vv := val.(type) {
-			case map[string]interface{}:
-				if f.Schema != nil {
-					nested, err := f.Schema.validateJSON(vv, mode)
-					if err != nil {
-						return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-					}
-					out[f.Name] = nested
-				} else {
-					out[f.Name] = vv
-				}
-			case []interface{}:
-				out[f.Name] = vv
-			case string:
-				// Try to parse as JSON string
-				var parsed interface{}
-				if err := json.Unmarshal([]byte(vv), &parsed); err != nil {
-					return nil, fmt.Errorf("field '%s' must be valid JSON", f.Name)
-				}
-				out[f.Name] = parsed
-			default:
-				return nil, fmt.Errorf("field '%s' must be json (object, array, or json string)", f.Name)
+			if _, err := compileRegex(tagParamUnescaper.Replace(expr)); err != nil {
+				panic(fmt.Sprintf("grape: invalid regex for field '%s': %v", f.Name, err))
 			}
-		case Slice:
-			svals, ok := val.([]interface{})
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be array", f.Name)
-			}
-			if f.SliceType == JSON && f.Schema != nil {
-				arr := make([]interface{}, 0, len(svals))
-				for _, elem := range svals {
-					m, ok := elem.(map[string]interface{})
-					if !ok {
-						return nil, fmt.Errorf("element in '%s' must be object", f.Name)
-					}
-					nested, err := f.Schema.validateJSON(m, mode)
-					if err != nil {
-						return nil, fmt.Errorf("element in '%s' validation failed: %w", f.Name, err)
-					}
-					arr = append(arr, nested)
+		}
+	}
+}
+
+// checkTag panics when the validator tag of a slice, map or JSON field
+// does not fit the arrays or objects it applies to.
+func (f Param) checkTag() {
+	if f.Validate == "" {
+		return
+	}
+	var samples []interface{}
+	switch {
+	case f.Type == Slice:
+		samples = []interface{}{[]interface{}{}}
+	case f.Type == Map || (f.Type == JSON && f.Schema != nil):
+		samples = []interface{}{map[string]interface{}{}}
+	case f.Type == JSON:
+		samples = []interface{}{map[string]interface{}{}, []interface{}{}}
+	}
+	for _, sample := range samples {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panic(fmt.Sprintf("grape: validator tag '%s' does not fit %s field '%s': %v", f.Validate, f.Type, f.Name, r))
 				}
-				out[f.Name] = arr
-			} else {
-				out[f.Name] = svals
-			}
-		default:
-			out[f.Name] = val
+			}()
+			_ = validate.Var(sample, f.Validate)
+		}()
+	}
+}
+
+// checkValues panics when the allowed values do not bind as f.
+func (f Param) checkValues() {
+	if len(f.Values) == 0 || f.Type == "" {
+		return
+	}
+	if f.Type != String && f.Type != Float && !isInteger(f.Type) {
+		panic(fmt.Sprintf("grape: Values is not supported for %s field '%s'", f.Type, f.Name))
+	}
+	var errs ValidationErrors
+	probe := f
+	probe.Validate = ""
+	for _, v := range f.Values {
+		if _, ok := (&Params{}).bindValue(probe, f.literal(v), pointer("", f.Name), "", &errs); !ok {
+			errs.localize("")
+			panic(fmt.Sprintf("grape: invalid value for field '%s': %v", f.Name, errs))
 		}
 	}
+}
 
-	for k, v := range raw {
-		if _, ok := out[k]; !ok {
-			out[k] = v
+// literal prepares a value declared in Go, such as a default, for binding
+// as f. Integer fields take Go integers as they are so large values stay
+// exact; other fields see them widened by jsonNumber. Go slices, such as
+// []string{"a"} for a SliceOf(String, nil) default, are prepared element
+// by element.
+func (f Param) literal(v interface{}) interface{} {
+	if isInteger(f.Type) {
+		return v
+	}
+	if rv := reflect.ValueOf(v); f.Type == Slice && rv.Kind() == reflect.Slice {
+		ep := f.elemParam()
+		arr := make([]interface{}, rv.Len())
+		for i := range arr {
+			arr[i] = ep.literal(rv.Index(i).Interface())
 		}
+		return arr
 	}
+	if f.Type == Map {
+		return f.mapLiteral(v)
+	}
+	return jsonNumber(v)
+}
 
-	return out, nil
+// jsonNumber widens Go numeric values to float64, the type encoding/json
+// decodes numbers into. Other values are returned unchanged.
+func jsonNumber(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	}
+	return v
 }
 
-// BindAndValidateReader binds JSON from an io.Reader and validates
+// BindAndValidateReader binds JSON from an io.Reader and validates. Numbers
+// are decoded with UseNumber so BigDecimal and integer fields keep their
+// exact value; all other fields bind as with BindAndValidate.
 func (p *Params) BindAndValidateReader(reader io.Reader, mode string) (Input, error) {
 	var raw map[string]interface{}
-	if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
 		return nil, err
 	}
 	return p.BindAndValidate(raw, mode)
@@ -459,71 +561,12 @@ func (p *Params) BindAndValidateReader(reader io.Reader, mode string) (Input, er
 // For direct map usage:
 //   input, err := schema.BindAndValidate(myMap, "create")
 
+// validateJSON binds raw as an object of p, exactly as BindAndValidate
+// does, and returns the bound fields as a plain map.
 func (p *Params) validateJSON(raw map[string]interface{}, mode string) (map[string]interface{}, error) {
-	b, _ := json.Marshal(raw)
-	var parsed map[string]interface{}
-	if err := json.Unmarshal(b, &parsed); err != nil {
+	out, errs := p.bindObject(raw, mode)
+	if err := errs.localize(p.locale); err != nil {
 		return nil, err
 	}
-
-	out := map[string]interface{}{}
-	for _, f := range p.Fields {
-		val, ok := parsed[f.Name]
-
-		isRequired := false
-		for _, r := range f.RequiredOn {
-			if strings.TrimSpace(r) == mode {
-				isRequired = true
-				break
-			}
-		}
-		if !ok {
-			if isRequired {
-				return nil, fmt.Errorf("missing required field '%s' for %s", f.Name, mode)
-			}
-			continue
-		}
-
-		switch f.Type {
-		case String:
-			s, ok := val.(string)
-			if !ok {
-				return nil, fmt.Errorf("field '%s' must be string", f.Name)
-			}
-			if f.Validate != "" {
-				if err := validate.Var(s, f.Validate); err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-			}
-			out[f.Name] = s
-		case Integer:
-			switch vv := val.(type) {
-			case float64:
-				out[f.Name] = int(vv)
-			case int:
-				out[f.Name] = vv
-			default:
-				return nil, fmt.Errorf("field '%s' must be integer", f.Name)
-			}
-		case JSON:
-			if f.Schema != nil {
-				nested, err := f.Schema.validateJSON(val.(map[string]interface{}), mode)
-				if err != nil {
-					return nil, fmt.Errorf("field '%s' validation failed: %w", f.Name, err)
-				}
-				out[f.Name] = nested
-			} else {
-				out[f.Name] = val
-			}
-		default:
-			out[f.Name] = val
-		}
-	}
-
-	for k, v := range parsed {
-		if _, ok := out[k]; !ok {
-			out[k] = v
-		}
-	}
 	return out, nil
 }
@@ -33,12 +33,25 @@
 // - TestBindAndValidateNestedJSON: Tests nested object validation
 // - TestBindAndValidateNestedSlice: Tests nested array validation
 // - TestBindAndValidateNestedSliceOfJSONs: Tests nested array of objects validation
+// - TestFieldBuilderDefault: Tests default value setup
+// - TestFieldBuilderDefaultTypeCheck: Tests that incompatible defaults panic at definition time
+// - TestBindAndValidateDefault: Tests defaults for absent fields
+// - TestBindAndValidateDefaultPerMode: Tests defaults restricted to modes
+// - TestBindAndValidateDefaultFunc: Tests computed defaults
+// - TestBindAndValidateNestedDefault: Tests defaults inside nested schemas
+// - TestFieldBuilderValues: Tests allowed value setup and definition-time checks
+// - TestBindAndValidateValues: Tests allowed values for string, integer and float fields
+// - TestBindAndValidateNestedValues: Tests allowed values inside nested schemas
+// - TestBindAndValidateNestedTypeParity: Tests that nested schemas check every field type like the top level
+// - TestBindAndValidateNestedNonObject: Tests type mismatches instead of panics for non-object nested values
+// - TestBindAndValidateReaderNestedExact: Tests exact nested numbers from io.Reader
 package grape
 
 import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 )
 
 // === Schema and Field Builder Tests ===
@@ -442,8 +455,10 @@ func TestValidateJSONWithExtraFields(t *testing.T) {
 	if result["extra"] != "value" {
 		t.Errorf("Expected extra 'value', got %v", result["extra"])
 	}
-	if result["another"] != 123.0 {
-		t.Errorf("Expected another 123.0, got %v", result["another"])
+	// Nested objects are no longer round-tripped through encoding/json,
+	// so passthrough values keep their Go type
+	if result["another"] != 123 {
+		t.Errorf("Expected another 123, got %v", result["another"])
 	}
 }
 
@@ -494,8 +509,9 @@ func TestBindAndValidateNestedSlice(t *testing.T) {
 	if len(nums) != 3 {
 		t.Errorf("Expected 3 numbers, got %d", len(nums))
 	}
-	if len(nums) != 3 || nums[0] != 10.0 || nums[1] != 20.0 || nums[2] != 30.0 {
-		t.Errorf("Expected [10.0 20.0 30.0], got %v", nums)
+	// Elements are bound as the declared SliceType
+	if len(nums) != 3 || nums[0] != 10 || nums[1] != 20 || nums[2] != 30 {
+		t.Errorf("Expected [10 20 30], got %v", nums)
 	}
 }
 
@@ -529,6 +545,274 @@ func TestBindAndValidateNestedSliceOfJSONs(t *testing.T) {
 	}
 }
 
+// === Default Value Tests ===
+
+func TestFieldBuilderDefault(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("per_page").Integer().Default(20, "list")
+	field := schema.Fields[0]
+	if field.Default != 20 {
+		t.Errorf("Expected default 20, got %v", field.Default)
+	}
+	if len(field.DefaultOn) != 1 || field.DefaultOn[0] != "list" {
+		t.Errorf("Expected DefaultOn [list], got %v", field.DefaultOn)
+	}
+}
+
+func TestFieldBuilderDefaultTypeCheck(t *testing.T) {
+	expectPanic := func(name string, build func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		build()
+	}
+
+	expectPanic("wrong type", func() { NewParams().Optional("n").Integer().Default("twenty") })
+	expectPanic("type set after default", func() { NewParams().Optional("n").Default("twenty").Integer() })
+	expectPanic("failing tag", func() { NewParams().Optional("n").Integer().Default(5).Validate("min=10") })
+
+	// Go integers are accepted for Integer and Float fields
+	_ = NewParams().Optional("n").Integer().Default(int64(5))
+	_ = NewParams().Optional("f").Float().Default(1)
+}
+
+func TestBindAndValidateDefault(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("per_page").Integer().Default(20)
+	_ = schema.Optional("sort").String().Default("name")
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"sort": "age"}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("per_page", 0) != 20 {
+		t.Errorf("Expected per_page 20, got %v", input["per_page"])
+	}
+	if input.String("sort") != "age" {
+		t.Errorf("Expected submitted sort 'age', got %v", input["sort"])
+	}
+}
+
+func TestBindAndValidateDefaultPerMode(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("status").On("create").String().Default("draft", "create")
+
+	input, err := schema.BindAndValidate(createTestJSON(`{}`), "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("status") != "draft" {
+		t.Errorf("Expected status 'draft' on create, got %v", input["status"])
+	}
+
+	input, err = schema.BindAndValidate(createTestJSON(`{}`), "update")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := input["status"]; ok {
+		t.Errorf("Expected no status on update, got %v", input["status"])
+	}
+}
+
+func TestBindAndValidateDefaultFunc(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("name").String()
+	_ = schema.Optional("slug").String().DefaultFunc(func(in Input) interface{} {
+		return strings.ToLower(in.String("name"))
+	})
+	_ = schema.Optional("limit").Integer().Validate("max=10").DefaultFunc(func(Input) interface{} {
+		return 50
+	})
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"name": "Hello"}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Field != "limit" {
+		t.Fatalf("Expected computed default to be validated, got %v", err)
+	}
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"name": "Hello", "limit": 5}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("slug") != "hello" {
+		t.Errorf("Expected slug 'hello', got %v", input["slug"])
+	}
+}
+
+func TestBindAndValidateNestedDefault(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("country").String().Default("US")
+	schema := NewParams()
+	_ = schema.Optional("address").JSON().WithSchema(sub)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"address": {}}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	addr := input["address"].(map[string]interface{})
+	if addr["country"] != "US" {
+		t.Errorf("Expected country 'US', got %v", addr["country"])
+	}
+}
+
+// === Allowed Values Tests ===
+
+func TestFieldBuilderValues(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("status").String().Values("draft", "published")
+	field := schema.Fields[0]
+	if len(field.Values) != 2 || field.Values[0] != "draft" || field.Values[1] != "published" {
+		t.Errorf("Expected values [draft published], got %v", field.Values)
+	}
+
+	expectPanic := func(name string, build func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		build()
+	}
+	expectPanic("wrong value type", func() { NewParams().Optional("n").Integer().Values(1, "two") })
+	expectPanic("unsupported type", func() { NewParams().Optional("b").Boolean().Values(true) })
+	expectPanic("default not allowed", func() { NewParams().Optional("s").String().Values("a", "b").Default("c") })
+}
+
+func TestBindAndValidateValues(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("status").String().Values("draft", "published")
+	_ = schema.Optional("priority").Integer().Values(1, 2, 3)
+	_ = schema.Optional("ratio").Float().Values(0.5, 1.0)
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"status": "draft", "priority": 2, "ratio": 0.5}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("priority", 0) != 2 {
+		t.Errorf("Expected priority 2, got %v", input["priority"])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"status": "deleted", "priority": 7, "ratio": 2}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	if verrs[0].Code != "validation_failed:values" {
+		t.Errorf("Expected values code, got %s", verrs[0].Code)
+	}
+	if verrs[0].Message != "field 'status' must be one of: draft, published" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+	if verrs[1].Message != "field 'priority' must be one of: 1, 2, 3" {
+		t.Errorf("Unexpected message %q", verrs[1].Message)
+	}
+}
+
+func TestBindAndValidateNestedValues(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("unit").String().Values("kg", "lb")
+	schema := NewParams()
+	_ = schema.Optional("weight").JSON().WithSchema(sub)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"weight": {"unit": "stone"}}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/weight/unit" {
+		t.Errorf("Expected error at /weight/unit, got %v", err)
+	}
+}
+
+func TestBindAndValidateNestedTypeParity(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("price").Float()
+	_ = sub.Optional("active").Boolean()
+	_ = sub.Optional("since").Date()
+	_ = sub.Optional("amount").BigDecimal().Scale(2)
+	_ = sub.Optional("tags").Slice()
+	_ = sub.Optional("qty").Integer().Validate("min=1")
+	schema := NewParams()
+	_ = schema.Optional("item").JSON().WithSchema(sub)
+	_ = schema.Optional("items").SliceOf(JSON, sub)
+
+	body := `{"item": {"price": "1", "active": "yes", "since": "01.01.2024", "amount": "1.005", "tags": "a", "qty": 0},
+		"items": [{"qty": 2.5}]}`
+	_, err := schema.BindAndValidate(createTestJSON(body), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 7 {
+		t.Fatalf("Expected 7 errors, got %v", err)
+	}
+	expected := []struct{ path, code string }{
+		{"/item/price", CodeTypeMismatch},
+		{"/item/active", CodeTypeMismatch},
+		{"/item/since", CodeTypeMismatch},
+		{"/item/amount", "validation_failed:scale"},
+		{"/item/tags", CodeTypeMismatch},
+		{"/item/qty", "validation_failed:min"},
+		{"/items/0/qty", CodeTypeMismatch},
+	}
+	for i, e := range expected {
+		if verrs[i].Path != e.path || verrs[i].Code != e.code {
+			t.Errorf("Error %d: expected %s at %s, got %s at %s", i, e.code, e.path, verrs[i].Code, verrs[i].Path)
+		}
+	}
+
+	body = `{"item": {"price": 1.5, "active": true, "since": "2024-01-01", "amount": "1.05", "qty": 3}}`
+	input, err := schema.BindAndValidate(createTestJSON(body), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	item := input["item"].(map[string]interface{})
+	if _, ok := item["since"].(time.Time); !ok {
+		t.Errorf("Expected nested date as time.Time, got %T", item["since"])
+	}
+	if item["qty"] != 3 || item["amount"].(Decimal).String() != "1.05" {
+		t.Errorf("Expected bound nested values, got %v", item)
+	}
+}
+
+func TestBindAndValidateNestedNonObject(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("city").String()
+	schema := NewParams()
+	_ = schema.Optional("address").JSON().WithSchema(sub)
+
+	for _, body := range []string{`{"address": "[1]"}`, `{"address": [1]}`, `{"address": 5}`} {
+		_, err := schema.BindAndValidate(createTestJSON(body), "")
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || verrs[0].Code != CodeTypeMismatch || verrs[0].Rule != "object" {
+			t.Errorf("%s: expected object type mismatch, got %v", body, err)
+		}
+	}
+
+	// A JSON string holding an object is bound against the schema
+	input, err := schema.BindAndValidate(map[string]interface{}{"address": `{"city": "Oslo"}`}, "")
+	if err != nil || input["address"].(map[string]interface{})["city"] != "Oslo" {
+		t.Errorf("Expected JSON string object to bind, got %v, %v", input, err)
+	}
+}
+
+func TestBindAndValidateReaderNestedExact(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Optional("id").Int64()
+	schema := NewParams()
+	_ = schema.Optional("owner").JSON().WithSchema(sub)
+
+	input, err := schema.BindAndValidateReader(strings.NewReader(`{"owner": {"id": 9007199254740993, "score": 1.5}}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	owner := input["owner"].(map[string]interface{})
+	if owner["id"] != int64(9007199254740993) {
+		t.Errorf("Expected exact nested id, got %v", owner["id"])
+	}
+	if owner["score"] != 1.5 {
+		t.Errorf("Expected float64 passthrough, got %#v", owner["score"])
+	}
+}
+
 // === Edge Cases ===
 
 func TestBindAndValidateEmptyJSON(t *testing.T) {
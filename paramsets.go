@@ -0,0 +1,141 @@
+package grape
+
+import "fmt"
+
+var paramSets = map[string]*Params{}
+
+// DefineParams registers a named, reusable param set, such as pagination
+// or an address block, for Params.Use. Like RegisterType it should be
+// called during initialization; defining a name twice panics.
+//
+//	grape.DefineParams("pagination", func(p *grape.Params) {
+//		_ = p.Optional("page").Integer().Default(1)
+//		_ = p.Optional("per_page").Integer().Default(20).Validate("max=100")
+//	})
+func DefineParams(name string, build func(*Params)) {
+	if _, ok := paramSets[name]; ok {
+		panic(fmt.Sprintf("grape: param set '%s' is already defined", name))
+	}
+	set := NewParams()
+	build(set)
+	paramSets[name] = set
+}
+
+// Use mixes the named param sets into p. Unknown names and fields that p
+// already declares panic.
+func (p *Params) Use(names ...string) *Params {
+	for _, name := range names {
+		set, ok := paramSets[name]
+		if !ok {
+			panic(fmt.Sprintf("grape: param set '%s' is not defined", name))
+		}
+		p.Merge(set)
+	}
+	return p
+}
+
+// Merge mixes the fields, group rules and conditional blocks of others
+// into p. A field declared by both sides panics; use Extend to override
+// fields.
+func (p *Params) Merge(others ...*Params) *Params {
+	for _, o := range others {
+		for _, f := range o.allFields() {
+			if p.declares(f.Name) {
+				panic(fmt.Sprintf("grape: field '%s' is already declared", f.Name))
+			}
+			for _, key := range f.keys() {
+				if p.accepts(key) {
+					panic(fmt.Sprintf("grape: name '%s' of field '%s' is already used", key, f.Name))
+				}
+			}
+		}
+		p.Fields = append(p.Fields, o.Fields...)
+		p.Groups = append(p.Groups, o.Groups...)
+		p.Conditions = append(p.Conditions, o.Conditions...)
+	}
+	return p
+}
+
+// Extend returns a copy of p with the fields declared by build added.
+// Fields build declares with the name of an existing field replace it in
+// place; a name or alias another field already uses panics. p is not
+// changed:
+//
+//	adminUser := userSchema.Extend(func(p *grape.Params) {
+//		_ = p.Requires("role").On("create").String()
+//		_ = p.Optional("email").String() // no longer required
+//	})
+func (p *Params) Extend(build func(*Params)) *Params {
+	ext := NewParams()
+	build(ext)
+
+	cp := p.clone()
+	for _, f := range ext.Fields {
+		replaced := false
+		for i := range cp.Fields {
+			if cp.Fields[i].Name == f.Name {
+				cp.Fields[i] = f
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			if cp.declares(f.Name) {
+				panic(fmt.Sprintf("grape: field '%s' is declared in a conditional block", f.Name))
+			}
+			cp.Fields = append(cp.Fields, f)
+		}
+	}
+	for _, f := range ext.Fields {
+		for _, key := range f.keys() {
+			cp.claim(f.Name, key)
+		}
+	}
+	for _, c := range ext.Conditions {
+		cp.claimBlock(c.Schema)
+	}
+	cp.Groups = append(cp.Groups, ext.Groups...)
+	cp.Conditions = append(cp.Conditions, ext.Conditions...)
+	return cp
+}
+
+// Without returns a copy of p without the named fields. Names p does not
+// declare, and fields still used by a group rule or as the condition of a
+// Given block, panic.
+func (p *Params) Without(names ...string) *Params {
+	cp := p.clone()
+	for _, name := range names {
+		idx := -1
+		for i, f := range cp.Fields {
+			if f.Name == name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			panic(fmt.Sprintf("grape: cannot remove undeclared field '%s'", name))
+		}
+		for _, g := range cp.Groups {
+			if containsString(g.Fields, name) {
+				panic(fmt.Sprintf("grape: cannot remove field '%s' used by %s", name, g.Rule))
+			}
+		}
+		for _, c := range cp.Conditions {
+			if c.Field == name {
+				panic(fmt.Sprintf("grape: cannot remove field '%s' used by Given", name))
+			}
+		}
+		cp.Fields = append(cp.Fields[:idx], cp.Fields[idx+1:]...)
+	}
+	return cp
+}
+
+// clone returns a copy of p whose field, group and condition lists can be
+// changed without affecting p.
+func (p *Params) clone() *Params {
+	cp := *p
+	cp.Fields = append([]Param{}, p.Fields...)
+	cp.Groups = append([]Group(nil), p.Groups...)
+	cp.Conditions = append([]Condition(nil), p.Conditions...)
+	return &cp
+}
@@ -0,0 +1,158 @@
+// Package grape provides tests for paramsets.go functionality.
+//
+// Test Functions:
+// - TestUseParamSet: Tests mixing named param sets into a schema
+// - TestUseParamSetConflicts: Tests definition-time panics for unknown sets and duplicate fields
+// - TestMerge: Tests merging fields, groups and conditional blocks
+// - TestExtend: Tests overriding and adding fields without changing the base schema, and name conflicts
+// - TestWithout: Tests removing fields and the conflicts it detects
+package grape
+
+import (
+	"testing"
+)
+
+func init() {
+	DefineParams("test_pagination", func(p *Params) {
+		_ = p.Optional("page").Integer().Default(1).Validate("min=1")
+		_ = p.Optional("per_page").Integer().Default(20).Validate("max=100")
+	})
+	DefineParams("test_sorting", func(p *Params) {
+		_ = p.Optional("sort").String().Values("name", "created_at")
+	})
+}
+
+func expectDefinitionPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestUseParamSet(t *testing.T) {
+	schema := NewParams().Use("test_pagination", "test_sorting")
+	_ = schema.Optional("q").String()
+
+	if len(schema.Fields) != 4 {
+		t.Fatalf("Expected 4 fields, got %d", len(schema.Fields))
+	}
+	input, err := schema.BindAndValidate(createTestJSON(`{"sort": "name"}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Integer("page", 0) != 1 || input.Integer("per_page", 0) != 20 {
+		t.Errorf("Expected pagination defaults, got %v", input)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"per_page": 500}`), ""); err == nil {
+		t.Error("Expected per_page rule from the set")
+	}
+
+	// Changing one schema leaves the shared set untouched
+	_ = schema.Optional("page").Integer().Default(2)
+	other := NewParams().Use("test_pagination")
+	if other.Fields[0].Default != 1 {
+		t.Errorf("Expected shared set to keep its default, got %v", other.Fields[0].Default)
+	}
+}
+
+func TestUseParamSetConflicts(t *testing.T) {
+	expectDefinitionPanic(t, "unknown set", func() { NewParams().Use("missing") })
+	expectDefinitionPanic(t, "duplicate set name", func() { DefineParams("test_sorting", func(*Params) {}) })
+	expectDefinitionPanic(t, "duplicate field", func() {
+		schema := NewParams()
+		_ = schema.Optional("page").String()
+		schema.Use("test_pagination")
+	})
+}
+
+func TestMerge(t *testing.T) {
+	contact := NewParams()
+	_ = contact.Optional("email").String()
+	_ = contact.Optional("phone").String()
+	contact.AtLeastOneOf("email", "phone")
+	contact.Given("phone", nil, func(p *Params) {
+		_ = p.Optional("sms_opt_in").Boolean()
+	})
+
+	schema := NewParams()
+	_ = schema.Requires("name").On("create").String()
+	schema.Merge(contact)
+
+	if len(schema.Fields) != 3 || len(schema.Groups) != 1 || len(schema.Conditions) != 1 {
+		t.Fatalf("Expected merged definitions, got %+v", schema)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"name": "A"}`), "create"); err == nil {
+		t.Error("Expected merged group rule to apply")
+	}
+
+	expectDefinitionPanic(t, "conditional field conflict", func() {
+		other := NewParams()
+		_ = other.Optional("sms_opt_in").Boolean()
+		other.Merge(contact)
+	})
+}
+
+func TestExtend(t *testing.T) {
+	base := NewParams()
+	_ = base.Requires("name").On("create").String()
+	_ = base.Requires("email").On("create").String().Validate("email")
+
+	admin := base.Extend(func(p *Params) {
+		_ = p.Optional("email").String()
+		_ = p.Requires("role").On("create").String().Values("admin", "owner")
+	})
+
+	if len(admin.Fields) != 3 || admin.Fields[1].Name != "email" || admin.Fields[2].Name != "role" {
+		t.Fatalf("Expected email replaced in place and role added, got %+v", admin.Fields)
+	}
+	if _, err := admin.BindAndValidate(createTestJSON(`{"name": "A", "role": "admin"}`), "create"); err != nil {
+		t.Errorf("Expected email to be optional in the extension, got %v", err)
+	}
+	if _, err := base.BindAndValidate(createTestJSON(`{"name": "A"}`), "create"); err == nil {
+		t.Error("Expected base schema to still require email")
+	}
+	if len(base.Fields) != 2 {
+		t.Errorf("Expected base fields unchanged, got %d", len(base.Fields))
+	}
+
+	expectDefinitionPanic(t, "conditional field conflict", func() {
+		withBlock := NewParams()
+		_ = withBlock.Optional("kind").String()
+		withBlock.Given("kind", nil, func(p *Params) { _ = p.Optional("extra").String() })
+		withBlock.Extend(func(p *Params) { _ = p.Optional("extra").Integer() })
+	})
+	expectDefinitionPanic(t, "external name conflict", func() {
+		base.Extend(func(p *Params) { _ = p.Optional("phone").String().As("email") })
+	})
+	expectDefinitionPanic(t, "replaced field conflict", func() {
+		base.Extend(func(p *Params) { _ = p.Optional("name").String().As("name", "email") })
+	})
+}
+
+func TestWithout(t *testing.T) {
+	base := NewParams().Use("test_pagination", "test_sorting").UnknownKeys(RejectUnknown)
+	_ = base.Optional("email").String()
+	_ = base.Optional("phone").String()
+	base.MutuallyExclusive("email", "phone")
+
+	lean := base.Without("per_page", "sort")
+	if len(lean.Fields) != 3 || len(base.Fields) != 5 {
+		t.Fatalf("Expected 3 fields in the projection and 5 in the base, got %d and %d", len(lean.Fields), len(base.Fields))
+	}
+	_, err := lean.BindAndValidate(createTestJSON(`{"sort": "name"}`), "")
+	if err == nil || err.(ValidationErrors)[0].Code != CodeUnknownField {
+		t.Errorf("Expected removed field to be unknown, got %v", err)
+	}
+
+	expectDefinitionPanic(t, "undeclared field", func() { base.Without("missing") })
+	expectDefinitionPanic(t, "field in group", func() { base.Without("email") })
+	expectDefinitionPanic(t, "field in condition", func() {
+		schema := NewParams()
+		_ = schema.Optional("kind").String()
+		schema.Given("kind", nil, func(p *Params) {})
+		schema.Without("kind")
+	})
+}
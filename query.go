@@ -0,0 +1,86 @@
+package grape
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// BindQuery binds URL query values, coercing each string into the declared
+// field type before running the same validation as BindAndValidate.
+// Repeated keys, or keys with a "[]" suffix, fill Slice fields; other fields
+// use the first value. Strings that cannot be coerced are left as is and
+// reported as type mismatches.
+func (p *Params) BindQuery(values url.Values, mode string) (Input, error) {
+	return p.BindAndValidate(p.coerceValues(values), mode)
+}
+
+// BindForm parses an application/x-www-form-urlencoded request body and
+// binds it like BindQuery. Query string parameters are not included.
+func (p *Params) BindForm(r *http.Request, mode string) (Input, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return p.BindQuery(r.PostForm, mode)
+}
+
+// coerceValues converts string values into the JSON-like values
+// BindAndValidate expects for each declared field.
+func (p *Params) coerceValues(values url.Values) map[string]interface{} {
+	raw := map[string]interface{}{}
+	for _, f := range p.allFields() {
+		for _, key := range f.keys() {
+			vals := append(append([]string(nil), values[key]...), values[key+"[]"]...)
+			if len(vals) > 0 {
+				raw[key] = f.coerceStrings(vals)
+			}
+		}
+	}
+
+	for k, vals := range values {
+		k = strings.TrimSuffix(k, "[]")
+		if _, ok := raw[k]; ok || len(vals) == 0 {
+			continue
+		}
+		raw[k] = plainStrings(vals)
+	}
+	return raw
+}
+
+// plainStrings returns a single value as a string and repeated values as
+// a []interface{} of strings.
+func plainStrings(vals []string) interface{} {
+	if len(vals) == 1 {
+		return vals[0]
+	}
+	arr := make([]interface{}, len(vals))
+	for i, s := range vals {
+		arr[i] = s
+	}
+	return arr
+}
+
+// coerceStrings converts the strings submitted for f.
+func (f Param) coerceStrings(vals []string) interface{} {
+	if f.Coerce != nil {
+		// The field's own coercion function receives the strings
+		return plainStrings(vals)
+	}
+	if f.Type == Slice {
+		arr := make([]interface{}, len(vals))
+		for i, s := range vals {
+			arr[i] = coerceString(f.SliceType, s)
+		}
+		return arr
+	}
+	return coerceString(f.Type, vals[0])
+}
+
+// coerceString converts s into the value a JSON body would carry for type t.
+// It returns s unchanged when it cannot be converted.
+func coerceString(t FieldType, s string) interface{} {
+	if c, ok := typeHandlers[t].(StringCoercer); ok {
+		return c.CoerceString(s)
+	}
+	return s
+}
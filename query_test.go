@@ -0,0 +1,163 @@
+// Package grape provides tests for query.go functionality.
+//
+// Test Functions:
+// - TestBindQueryCoercesTypes: Tests string coercion for integer, float, boolean and string fields
+// - TestBindQueryBooleanForms: Tests accepted boolean spellings
+// - TestBindQuerySlices: Tests repeated keys and "[]" suffixed keys for slice fields
+// - TestBindQueryJSON: Tests JSON query values with nested schemas
+// - TestBindQueryErrors: Tests type and validation failures for uncoercible strings
+// - TestBindQueryExtraKeys: Tests passthrough of undeclared keys
+// - TestBindForm: Tests binding of url-encoded request bodies
+package grape
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBindQueryCoercesTypes(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("q").String()
+	_ = schema.Optional("page").Integer().Validate("min=1")
+	_ = schema.Optional("ratio").Float()
+	_ = schema.Optional("active").Boolean()
+
+	values, _ := url.ParseQuery("q=shoes&page=42&ratio=0.5&active=true")
+	input, err := schema.BindQuery(values, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("q") != "shoes" {
+		t.Errorf("Expected q 'shoes', got %v", input["q"])
+	}
+	if input.Integer("page", 0) != 42 {
+		t.Errorf("Expected page 42, got %v", input["page"])
+	}
+	if input.Float("ratio", 0) != 0.5 {
+		t.Errorf("Expected ratio 0.5, got %v", input["ratio"])
+	}
+	if input.Boolean("active", false) != true {
+		t.Errorf("Expected active true, got %v", input["active"])
+	}
+}
+
+func TestBindQueryBooleanForms(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("flag").Boolean()
+
+	tests := map[string]bool{"true": true, "1": true, "on": true, "yes": true, "false": false, "0": false, "off": false, "no": false}
+	for s, expected := range tests {
+		input, err := schema.BindQuery(url.Values{"flag": {s}}, "")
+		if err != nil {
+			t.Errorf("%q: expected no error, got %v", s, err)
+			continue
+		}
+		if v, ok := input["flag"].(bool); !ok || v != expected {
+			t.Errorf("%q: expected %v, got %v", s, expected, input["flag"])
+		}
+	}
+}
+
+func TestBindQuerySlices(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("ids").SliceOf(Integer, nil)
+	_ = schema.Optional("tags").SliceOf(String, nil)
+
+	values, _ := url.ParseQuery("ids=1&ids=2&ids=3&tags[]=a&tags[]=b")
+	input, err := schema.BindQuery(values, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ids, ok := input["ids"].([]interface{})
+	if !ok || len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("Expected ids [1 2 3], got %v", input["ids"])
+	}
+	tags, ok := input["tags"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("Expected tags [a b], got %v", input["tags"])
+	}
+}
+
+func TestBindQueryJSON(t *testing.T) {
+	sub := NewParams()
+	_ = sub.Requires("field").On("search").String()
+	schema := NewParams()
+	_ = schema.Optional("sort").JSON().WithSchema(sub)
+
+	input, err := schema.BindQuery(url.Values{"sort": {`{"field": "name"}`}}, "search")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sort, ok := input["sort"].(map[string]interface{})
+	if !ok || sort["field"] != "name" {
+		t.Errorf("Expected sort.field 'name', got %v", input["sort"])
+	}
+
+	_, err = schema.BindQuery(url.Values{"sort": {`{}`}}, "search")
+	if err == nil || !strings.Contains(err.Error(), "missing required field 'field'") {
+		t.Errorf("Expected nested required error, got %v", err)
+	}
+}
+
+func TestBindQueryErrors(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("page").Integer()
+	_ = schema.Optional("active").Boolean()
+	_ = schema.Optional("per_page").Integer().Validate("max=100")
+
+	values, _ := url.ParseQuery("page=two&active=maybe&per_page=500")
+	_, err := schema.BindQuery(values, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", err)
+	}
+	if verrs[0].Code != CodeTypeMismatch || verrs[0].Value != "two" {
+		t.Errorf("Expected type mismatch for page, got %+v", verrs[0])
+	}
+	if verrs[1].Code != CodeTypeMismatch || verrs[1].Value != "maybe" {
+		t.Errorf("Expected type mismatch for active, got %+v", verrs[1])
+	}
+	if verrs[2].Code != "validation_failed:max" {
+		t.Errorf("Expected max failure for per_page, got %+v", verrs[2])
+	}
+}
+
+func TestBindQueryExtraKeys(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("q").String()
+
+	values, _ := url.ParseQuery("q=x&utm=mail&ref=a&ref=b")
+	input, err := schema.BindQuery(values, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("utm") != "mail" {
+		t.Errorf("Expected utm 'mail', got %v", input["utm"])
+	}
+	if ref, ok := input["ref"].([]interface{}); !ok || len(ref) != 2 {
+		t.Errorf("Expected ref to hold both values, got %v", input["ref"])
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("name").On("create").String()
+	_ = schema.Optional("age").Integer()
+
+	r := httptest.NewRequest(http.MethodPost, "/users?age=1", strings.NewReader("name=John&age=30"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	input, err := schema.BindForm(r, "create")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("name") != "John" {
+		t.Errorf("Expected name 'John', got %v", input["name"])
+	}
+	if input.Integer("age", 0) != 30 {
+		t.Errorf("Expected age from body 30, got %v", input["age"])
+	}
+}
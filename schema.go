@@ -0,0 +1,463 @@
+package grape
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SchemaDialect is the JSON Schema version JSONSchema documents declare.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaWarning reports a rule JSONSchema could not express, such as a
+// validator tag without a JSON Schema keyword or a Given block.
+type SchemaWarning struct {
+	Path string // JSON pointer of the field's schema in the document, e.g. "/properties/items/items"
+	Rule string // the rule left out, e.g. "alphanum" or "given"
+}
+
+func (w SchemaWarning) String() string { return w.Path + ": " + w.Rule }
+
+// JSONSchema describes the request body p accepts in mode as a JSON Schema
+// (draft 2020-12) document: field types, nested schemas, the fields
+// required in mode, Values, defaults, group rules and the validator tags
+// min, max, len, eq, gt, gte, lt, lte, email, url, uri, uuid, oneof and
+// regex; rules after dive describe the elements or values. Given blocks
+// become optional properties plus if/then rules requiring the block's
+// required fields when an Equals or nil predicate holds. Recursive
+// schemas, such as one derived from a tree type, are described once under
+// $defs and referred to with $ref. Rules it cannot express, such as other
+// validator tags, other predicates and CoerceWith functions, are returned
+// as warnings.
+//
+//	doc, warnings := schema.JSONSchema("create")
+//	for _, w := range warnings {
+//		log.Printf("not in the schema: %s", w)
+//	}
+func (p *Params) JSONSchema(mode string) (map[string]interface{}, []SchemaWarning) {
+	w := &schemaWriter{mode: mode, recursive: map[schemaKey]bool{}, names: map[schemaKey]string{}, defs: map[string]interface{}{}}
+	root := schemaKey{p, p.unknownKeys()}
+	w.findRecursive(p, root, map[schemaKey]bool{}, map[schemaKey]bool{})
+
+	var doc map[string]interface{}
+	if w.recursive[root] {
+		doc = map[string]interface{}{"type": "object", "$ref": w.define(root, p)}
+	} else {
+		doc = w.objectSchema(p, "")
+	}
+	if len(w.defs) > 0 {
+		doc["$defs"] = w.defs
+	}
+	doc["$schema"] = SchemaDialect
+	return doc, w.warnings
+}
+
+// schemaKey identifies a nested schema as JSONSchema describes it: the
+// declared Params and the unknown-key policy it is bound with.
+type schemaKey struct {
+	p       *Params
+	unknown UnknownKeyPolicy
+}
+
+// schemaWriter holds the state of a JSONSchema call.
+type schemaWriter struct {
+	mode      string
+	warnings  []SchemaWarning
+	recursive map[schemaKey]bool   // schemas nested within themselves
+	names     map[schemaKey]string // names of the schemas under $defs
+	defs      map[string]interface{}
+}
+
+// findRecursive marks the schemas nested in p, whose key is key, that are
+// nested within themselves. active holds the schemas being searched and
+// done those already searched.
+func (w *schemaWriter) findRecursive(p *Params, key schemaKey, active, done map[schemaKey]bool) {
+	active[key] = true
+	fields := p.Fields
+	for _, c := range p.Conditions {
+		fields = append(fields[:len(fields):len(fields)], c.Schema.within(p).allFields()...)
+	}
+	for _, f := range fields {
+		if f.Schema == nil {
+			continue
+		}
+		sub := f.Schema.within(p)
+		k := schemaKey{f.Schema, sub.unknownKeys()}
+		if active[k] {
+			w.recursive[k] = true
+		} else if !done[k] {
+			w.findRecursive(sub, k, active, done)
+		}
+	}
+	active[key] = false
+	done[key] = true
+}
+
+// define describes the recursive schema p, whose key is key, under $defs
+// unless it already is, and returns the $ref pointing to it. The type
+// stays with the referring schema, so that it can be nullable.
+func (w *schemaWriter) define(key schemaKey, p *Params) string {
+	name, ok := w.names[key]
+	if !ok {
+		name = "schema" + strconv.Itoa(len(w.names)+1)
+		w.names[key] = name
+		s := w.objectSchema(p, pointer(pointer("", "$defs"), name))
+		delete(s, "type")
+		w.defs[name] = s
+	}
+	return "#" + pointer(pointer("", "$defs"), name)
+}
+
+// objectSchema describes an object of p. path is the JSON pointer of the
+// object's schema in the document.
+func (w *schemaWriter) objectSchema(p *Params, path string) map[string]interface{} {
+	props := map[string]interface{}{}
+	var required []string
+	describe := func(fields []Param, optional bool) {
+		for _, f := range fields {
+			f = f.external()
+			s := w.fieldSchema(p, f, pointer(pointer(path, "properties"), f.Name))
+			props[f.Name] = s
+			for _, alias := range f.Aliases {
+				props[alias] = map[string]interface{}{"$ref": "#" + pointer(pointer(path, "properties"), f.Name), "deprecated": true}
+			}
+			if !optional && f.requiredIn(w.mode) {
+				required = append(required, f.Name)
+			}
+		}
+	}
+	describe(p.Fields, false)
+	for _, c := range p.Conditions {
+		describe(c.Schema.within(p).allFields(), true)
+	}
+
+	s := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if p.unknownKeys() == RejectUnknown {
+		s["additionalProperties"] = false
+	}
+	if rules := append(p.groupSchemas(w.mode), w.conditionSchemas(p, p, path)...); len(rules) > 0 {
+		s["allOf"] = rules
+	}
+	return s
+}
+
+// conditionSchemas translates the Given blocks of block, p or one of its
+// blocks, into if/then schemas requiring the block's required fields when
+// the condition holds. Only Equals and nil predicates can be expressed;
+// other predicates are recorded as "given" warnings.
+func (w *schemaWriter) conditionSchemas(p, block *Params, path string) []interface{} {
+	var rules []interface{}
+	for _, c := range block.Conditions {
+		name := c.Field
+		if f, ok := block.field(c.Field); ok {
+			name = f.external().Name
+		} else if f, ok := p.field(c.Field); ok {
+			name = f.external().Name
+		}
+		if c.Predicate != nil && c.Values == nil {
+			w.warnings = append(w.warnings, SchemaWarning{Path: pointer(pointer(path, "properties"), name), Rule: "given"})
+			continue
+		}
+
+		cond := map[string]interface{}{"required": []string{name}}
+		switch len(c.Values) {
+		case 0:
+		case 1:
+			cond["properties"] = map[string]interface{}{name: map[string]interface{}{"const": c.Values[0]}}
+		default:
+			cond["properties"] = map[string]interface{}{name: map[string]interface{}{"enum": c.Values}}
+		}
+		then := map[string]interface{}{}
+		var required []string
+		for _, f := range c.Schema.Fields {
+			if f.requiredIn(w.mode) {
+				required = append(required, f.external().Name)
+			}
+		}
+		if len(required) > 0 {
+			then["required"] = required
+		}
+		if nested := w.conditionSchemas(p, c.Schema, path); len(nested) > 0 {
+			then["allOf"] = nested
+		}
+		if len(then) > 0 {
+			rules = append(rules, map[string]interface{}{"if": cond, "then": then})
+		}
+	}
+	return rules
+}
+
+// requiredIn reports whether f is required in mode.
+func (f Param) requiredIn(mode string) bool {
+	for _, r := range f.RequiredOn {
+		if r = strings.TrimSpace(r); r == mode || r == AllModes {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldSchema describes the values of f, a field of p.
+func (w *schemaWriter) fieldSchema(p *Params, f Param, path string) map[string]interface{} {
+	s := describeType(f)
+	switch {
+	case f.Type == Slice && f.SliceType != "":
+		s["items"] = w.fieldSchema(p, f.elemParam(), pointer(path, "items"))
+	case f.Type == Map && f.ValueType != "":
+		s["additionalProperties"] = w.fieldSchema(p, f.valueParam(), pointer(path, "additionalProperties"))
+	case f.Schema != nil && f.Type != Slice && f.Type != Map:
+		sub := f.Schema.within(p)
+		if key := (schemaKey{f.Schema, sub.unknownKeys()}); w.recursive[key] {
+			s["$ref"] = w.define(key, sub)
+			break
+		}
+		for k, v := range w.objectSchema(sub, path) {
+			s[k] = v
+		}
+	}
+
+	w.translateTag(s, splitTag(f.Validate), path)
+	if len(f.Values) > 0 {
+		s["enum"] = f.Values
+	}
+	if f.Default != nil && (len(f.DefaultOn) == 0 || containsString(f.DefaultOn, w.mode)) {
+		s["default"] = f.Default
+	}
+	if f.Coerce != nil {
+		w.warnings = append(w.warnings, SchemaWarning{Path: path, Rule: "coerce"})
+	}
+	if f.Nullable {
+		switch t := s["type"].(type) {
+		case string:
+			s["type"] = []interface{}{t, "null"}
+		case []interface{}:
+			s["type"] = append(append([]interface{}(nil), t...), "null")
+		}
+	}
+	return s
+}
+
+// translateTag adds the JSON Schema keywords for the validator rules to s,
+// the schema at path, and records a warning for each rule it can't
+// express. The rules after dive apply to the elements or values.
+func (w *schemaWriter) translateTag(s map[string]interface{}, rules []string, path string) {
+	for i, rule := range rules {
+		if rule == "dive" {
+			w.translateDive(s, rules[i+1:], path)
+			return
+		}
+		if !translateRule(s, rule) {
+			w.warnings = append(w.warnings, SchemaWarning{Path: path, Rule: rule})
+		}
+	}
+}
+
+// translateDive adds the rules following a dive to the items or
+// additionalProperties of s. A keys section, and rules for elements s does
+// not describe, are recorded as warnings.
+func (w *schemaWriter) translateDive(s map[string]interface{}, rules []string, path string) {
+	if len(rules) > 0 && rules[0] == "keys" {
+		end := len(rules) - 1
+		for i, rule := range rules {
+			if rule == "endkeys" {
+				end = i
+				break
+			}
+		}
+		w.warnings = append(w.warnings, SchemaWarning{Path: path, Rule: strings.Join(rules[:end+1], ",")})
+		rules = rules[end+1:]
+	}
+	if len(rules) == 0 {
+		return
+	}
+	for _, keyword := range []string{"items", "additionalProperties"} {
+		if elem, ok := s[keyword].(map[string]interface{}); ok {
+			w.translateTag(elem, rules, pointer(path, keyword))
+			return
+		}
+	}
+	w.warnings = append(w.warnings, SchemaWarning{Path: path, Rule: "dive," + strings.Join(rules, ",")})
+}
+
+// groupSchemas translates the group rules of p that apply in mode.
+func (p *Params) groupSchemas(mode string) []interface{} {
+	var rules []interface{}
+	for _, g := range p.Groups {
+		if len(g.On) > 0 && !containsString(g.On, mode) {
+			continue
+		}
+		names := make([]string, len(g.Fields))
+		for i, name := range g.Fields {
+			f, _ := p.field(name)
+			names[i] = f.external().Name
+		}
+		each := func(sets [][]string) []interface{} {
+			out := make([]interface{}, len(sets))
+			for i, set := range sets {
+				out[i] = map[string]interface{}{"required": set}
+			}
+			return out
+		}
+		var singles, pairs [][]string
+		for i, a := range names {
+			singles = append(singles, []string{a})
+			for _, b := range names[i+1:] {
+				pairs = append(pairs, []string{a, b})
+			}
+		}
+		switch g.Rule {
+		case MutuallyExclusive:
+			rules = append(rules, map[string]interface{}{"not": map[string]interface{}{"anyOf": each(pairs)}})
+		case ExactlyOneOf:
+			rules = append(rules, map[string]interface{}{"oneOf": each(singles)})
+		case AtLeastOneOf:
+			rules = append(rules, map[string]interface{}{"anyOf": each(singles)})
+		case AllOrNoneOf:
+			deps := map[string]interface{}{}
+			for _, a := range names {
+				var others []string
+				for _, b := range names {
+					if b != a {
+						others = append(others, b)
+					}
+				}
+				deps[a] = others
+			}
+			rules = append(rules, map[string]interface{}{"dependentRequired": deps})
+		}
+	}
+	return rules
+}
+
+// splitTag splits a validator tag into its rules. "omitempty" adds no
+// constraint and is dropped.
+func splitTag(tag string) []string {
+	var rules []string
+	for _, rule := range strings.Split(tag, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" && rule != "omitempty" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// translateRule adds the JSON Schema keywords for the validator rule to
+// s, the schema of the value the rule applies to. It reports false when
+// the rule has no equivalent for values of that type.
+func translateRule(s map[string]interface{}, rule string) bool {
+	name, param, _ := strings.Cut(rule, "=")
+	param = tagParamUnescaper.Replace(param)
+	kind, _ := s["type"].(string)
+	if kind == "integer" {
+		kind = "number"
+	}
+
+	switch name {
+	case "email":
+		return setFormat(s, kind, "email")
+	case "url", "uri":
+		return setFormat(s, kind, "uri")
+	case "uuid":
+		return setFormat(s, kind, "uuid")
+	case "regex":
+		if kind != "string" {
+			return false
+		}
+		s["pattern"] = param
+		return true
+	case "required":
+		switch kind {
+		case "string":
+			s["minLength"] = 1
+		case "array":
+			s["minItems"] = 1
+		case "object":
+			s["minProperties"] = 1
+		default:
+			return false
+		}
+		return true
+	case "oneof":
+		var enum []interface{}
+		for _, v := range strings.Fields(param) {
+			switch kind {
+			case "string":
+				enum = append(enum, v)
+			case "number":
+				n, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return false
+				}
+				enum = append(enum, n)
+			default:
+				return false
+			}
+		}
+		s["enum"] = enum
+		return true
+	}
+
+	if kind == "number" {
+		keyword, ok := map[string]string{
+			"min": "minimum", "gte": "minimum", "gt": "exclusiveMinimum",
+			"max": "maximum", "lte": "maximum", "lt": "exclusiveMaximum",
+			"eq": "const", "len": "const",
+		}[name]
+		n, err := strconv.ParseFloat(param, 64)
+		if !ok || err != nil {
+			return false
+		}
+		s[keyword] = n
+		return true
+	}
+
+	// eq compares the value of a string, not its length
+	if kind == "string" && name == "eq" {
+		s["const"] = param
+		return true
+	}
+
+	// For strings, arrays and objects the rules limit the length
+	keywords, ok := lengthKeywords[kind]
+	n, err := strconv.Atoi(param)
+	if !ok || err != nil {
+		return false
+	}
+	switch name {
+	case "min", "gte":
+		s[keywords[0]] = n
+	case "gt":
+		s[keywords[0]] = n + 1
+	case "max", "lte":
+		s[keywords[1]] = n
+	case "lt":
+		s[keywords[1]] = n - 1
+	case "len", "eq":
+		s[keywords[0]], s[keywords[1]] = n, n
+	default:
+		return false
+	}
+	return true
+}
+
+// tagParamUnescaper decodes the commas and pipes of validator parameters.
+var tagParamUnescaper = strings.NewReplacer("0x2C", ",", "0x7C", "|")
+
+// lengthKeywords holds the minimum and maximum length keywords of each
+// JSON Schema type with a length.
+var lengthKeywords = map[string][2]string{
+	"string": {"minLength", "maxLength"},
+	"array":  {"minItems", "maxItems"},
+	"object": {"minProperties", "maxProperties"},
+}
+
+// setFormat sets the format of a string schema.
+func setFormat(s map[string]interface{}, kind, format string) bool {
+	if kind != "string" {
+		return false
+	}
+	s["format"] = format
+	return true
+}
@@ -0,0 +1,317 @@
+// Package grape provides tests for schema.go functionality.
+//
+// Test Functions:
+// - TestJSONSchemaTypes: Tests the schema of each field type
+// - TestJSONSchemaRequired: Tests mode-specific required lists and defaults
+// - TestJSONSchemaNested: Tests nested schemas, slices and maps of objects
+// - TestJSONSchemaRecursive: Tests describing recursive schemas once under $defs
+// - TestJSONSchemaTags: Tests translating validator tags per value type
+// - TestJSONSchemaWarnings: Tests reporting rules the schema can't express
+// - TestJSONSchemaGroups: Tests translating group rules
+// - TestJSONSchemaConditions: Tests translating Given blocks into if/then
+// - TestJSONSchemaNamesAndNulls: Tests external names, aliases, nullable fields and unknown keys
+// - TestRegexValidator: Tests the regex validator tag
+package grape
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// schemaJSON returns doc as compact JSON for comparisons.
+func schemaJSON(t *testing.T, doc interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return string(b)
+}
+
+func TestJSONSchemaTypes(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("s").String()
+	_ = schema.Optional("i").Integer()
+	_ = schema.Optional("i64").Int64()
+	_ = schema.Optional("u").Uint()
+	_ = schema.Optional("f").Float()
+	_ = schema.Optional("d").BigDecimal()
+	_ = schema.Optional("b").Boolean()
+	_ = schema.Optional("day").Date()
+	_ = schema.Optional("ids").SliceOf(Integer, nil).MaxItems(3)
+	_ = schema.Optional("labels").MapOf(KeyIn("env"), String, nil)
+
+	doc, warnings := schema.JSONSchema("")
+	if len(warnings) != 0 {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+	if doc["$schema"] != SchemaDialect || doc["type"] != "object" {
+		t.Errorf("Unexpected document header %v", doc)
+	}
+	props := doc["properties"].(map[string]interface{})
+	want := map[string]string{
+		"s":      `{"type":"string"}`,
+		"i":      `{"type":"integer"}`,
+		"i64":    `{"format":"int64","type":"integer"}`,
+		"u":      `{"minimum":0,"type":"integer"}`,
+		"f":      `{"type":"number"}`,
+		"d":      `{"format":"decimal","type":["string","number"]}`,
+		"b":      `{"type":"boolean"}`,
+		"day":    `{"format":"date","type":"string"}`,
+		"ids":    `{"items":{"type":"integer"},"maxItems":3,"type":"array"}`,
+		"labels": `{"additionalProperties":{"type":"string"},"propertyNames":{"enum":["env"]},"type":"object"}`,
+	}
+	for name, w := range want {
+		if got := schemaJSON(t, props[name]); got != w {
+			t.Errorf("%s: expected %s, got %s", name, w, got)
+		}
+	}
+}
+
+func TestJSONSchemaRequired(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("name").On("create", "update").String()
+	_ = schema.Requires("email").On("create").String()
+	_ = schema.Optional("status").String().Default("draft", "create").Values("draft", "live")
+
+	create, _ := schema.JSONSchema("create")
+	if !reflect.DeepEqual(create["required"], []string{"name", "email"}) {
+		t.Errorf("Expected name and email required, got %v", create["required"])
+	}
+	status := create["properties"].(map[string]interface{})["status"].(map[string]interface{})
+	if status["default"] != "draft" || !reflect.DeepEqual(status["enum"], []interface{}{"draft", "live"}) {
+		t.Errorf("Unexpected status schema %v", status)
+	}
+
+	update, _ := schema.JSONSchema("update")
+	if !reflect.DeepEqual(update["required"], []string{"name"}) {
+		t.Errorf("Expected name required, got %v", update["required"])
+	}
+	if _, ok := update["properties"].(map[string]interface{})["status"].(map[string]interface{})["default"]; ok {
+		t.Error("Expected no default outside create")
+	}
+}
+
+func TestJSONSchemaNested(t *testing.T) {
+	item := NewParams()
+	_ = item.Requires("sku").On("create").String()
+	address := NewParams().UnknownKeys(RejectUnknown)
+	_ = address.Requires("city").On("create").String()
+
+	schema := NewParams()
+	_ = schema.Optional("address").JSON().WithSchema(address)
+	_ = schema.Optional("items").SliceOf(JSON, item).Validate("min=1")
+	_ = schema.Optional("prices").MapOf(KeyRule{}, JSON, item)
+
+	doc, warnings := schema.JSONSchema("create")
+	if len(warnings) != 0 {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+	props := doc["properties"].(map[string]interface{})
+	want := map[string]string{
+		"address": `{"additionalProperties":false,"properties":{"city":{"type":"string"}},"required":["city"],"type":"object"}`,
+		"items":   `{"items":{"properties":{"sku":{"type":"string"}},"required":["sku"],"type":"object"},"minItems":1,"type":"array"}`,
+		"prices":  `{"additionalProperties":{"properties":{"sku":{"type":"string"}},"required":["sku"],"type":"object"},"type":"object"}`,
+	}
+	for name, w := range want {
+		if got := schemaJSON(t, props[name]); got != w {
+			t.Errorf("%s: expected %s, got %s", name, w, got)
+		}
+	}
+}
+
+func TestJSONSchemaRecursive(t *testing.T) {
+	node := NewParams()
+	_ = node.Optional("name").String().Validate("alphanum")
+	_ = node.Optional("children").SliceOf(JSON, node)
+	_ = node.Optional("parent").JSON().WithSchema(node).Nullable()
+
+	doc, warnings := node.JSONSchema("")
+	want := `{"$defs":{"schema1":{"properties":{` +
+		`"children":{"items":{"$ref":"#/$defs/schema1","type":"object"},"type":"array"},` +
+		`"name":{"type":"string"},` +
+		`"parent":{"$ref":"#/$defs/schema1","type":["object","null"]}}}},` +
+		`"$ref":"#/$defs/schema1","$schema":"` + SchemaDialect + `","type":"object"}`
+	if got := schemaJSON(t, doc); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if !reflect.DeepEqual(warnings, []SchemaWarning{{Path: "/$defs/schema1/properties/name", Rule: "alphanum"}}) {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+
+	// A recursive type nested in another is referred to from its field
+	type Tree struct {
+		Root  structNode
+		Title string
+	}
+	doc, _ = ParamsFromStruct(Tree{}).JSONSchema("")
+	props := doc["properties"].(map[string]interface{})
+	if got := schemaJSON(t, props["root"]); got != `{"$ref":"#/$defs/schema1","type":"object"}` {
+		t.Errorf("Unexpected root %s", got)
+	}
+	if got := schemaJSON(t, doc["$defs"]); got != `{"schema1":{"properties":{"children":{"items":{"$ref":"#/$defs/schema1","type":"object"},"type":"array"},"name":{"type":"string"}}}}` {
+		t.Errorf("Unexpected $defs %s", got)
+	}
+}
+
+func TestJSONSchemaTags(t *testing.T) {
+	tests := []struct {
+		field func(p *Params)
+		want  string
+	}{
+		{func(p *Params) { _ = p.Optional("v").String().Validate("required,min=2,max=10") }, `{"maxLength":10,"minLength":2,"type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("len=3") }, `{"maxLength":3,"minLength":3,"type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("eq=3") }, `{"const":"3","type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("omitempty,email") }, `{"format":"email","type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("url") }, `{"format":"uri","type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("oneof=red green") }, `{"enum":["red","green"],"type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").String().Validate("regex=^[a-z]{10x2C3}$") }, `{"pattern":"^[a-z]{1,3}$","type":"string"}`},
+		{func(p *Params) { _ = p.Optional("v").Integer().Validate("min=1,lt=100") }, `{"exclusiveMaximum":100,"minimum":1,"type":"integer"}`},
+		{func(p *Params) { _ = p.Optional("v").Float().Validate("oneof=1.5 2") }, `{"enum":[1.5,2],"type":"number"}`},
+		{func(p *Params) { _ = p.Optional("v").SliceOf(String, nil).Validate("max=5").ElemValidate("email") }, `{"items":{"format":"email","type":"string"},"maxItems":5,"type":"array"}`},
+		{func(p *Params) { _ = p.Optional("v").SliceOf(String, nil).Validate("max=5,dive,min=3") }, `{"items":{"minLength":3,"type":"string"},"maxItems":5,"type":"array"}`},
+		{func(p *Params) { _ = p.Optional("v").MapOf(KeyRule{}, Integer, nil).Validate("dive,gt=0") }, `{"additionalProperties":{"exclusiveMinimum":0,"type":"integer"},"type":"object"}`},
+	}
+	for _, tt := range tests {
+		schema := NewParams()
+		tt.field(schema)
+		doc, warnings := schema.JSONSchema("")
+		if len(warnings) != 0 {
+			t.Errorf("%s: unexpected warnings %v", tt.want, warnings)
+		}
+		if got := schemaJSON(t, doc["properties"].(map[string]interface{})["v"]); got != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestJSONSchemaWarnings(t *testing.T) {
+	item := NewParams()
+	_ = item.Optional("code").String().Validate("alphanum")
+
+	schema := NewParams()
+	_ = schema.Optional("name").String().Validate("min=2,email|url")
+	_ = schema.Optional("amount").BigDecimal().Validate("min=1")
+	_ = schema.Optional("items").SliceOf(JSON, item)
+	_ = schema.Optional("tags").String().CoerceWith(splitCSV)
+	_ = schema.Optional("labels").MapOf(KeyRule{}, String, nil).Validate("dive,keys,alpha,endkeys,min=1")
+	_ = schema.Optional("raw").SliceOf("", nil).Validate("dive,min=1")
+	_ = schema.Optional("method").String()
+	schema.Given("method", func(v interface{}) bool { return v != "cash" }, func(p *Params) {
+		_ = p.Requires("card_number").On("create").String()
+	})
+
+	doc, warnings := schema.JSONSchema("create")
+	want := []SchemaWarning{
+		{Path: "/properties/name", Rule: "email|url"},
+		{Path: "/properties/amount", Rule: "min=1"},
+		{Path: "/properties/items/items/properties/code", Rule: "alphanum"},
+		{Path: "/properties/tags", Rule: "coerce"},
+		{Path: "/properties/labels", Rule: "keys,alpha,endkeys"},
+		{Path: "/properties/raw", Rule: "dive,min=1"},
+		{Path: "/properties/method", Rule: "given"},
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Expected %v, got %v", want, warnings)
+	}
+	if warnings[0].String() != "/properties/name: email|url" {
+		t.Errorf("Unexpected string %q", warnings[0].String())
+	}
+	props := doc["properties"].(map[string]interface{})
+	if _, ok := props["card_number"]; !ok || doc["required"] != nil {
+		t.Errorf("Expected card_number as an optional property, got %v", doc)
+	}
+}
+
+func TestJSONSchemaGroups(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("email").String().As("emailAddress")
+	_ = schema.Optional("phone").String()
+	_ = schema.Optional("lat").Float()
+	_ = schema.Optional("lng").Float()
+	schema.MutuallyExclusive("email", "phone")
+	schema.ExactlyOneOf("email", "phone").On("update")
+	schema.AllOrNoneOf("lat", "lng")
+
+	doc, _ := schema.JSONSchema("create")
+	want := `[{"not":{"anyOf":[{"required":["emailAddress","phone"]}]}},{"dependentRequired":{"lat":["lng"],"lng":["lat"]}}]`
+	if got := schemaJSON(t, doc["allOf"]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	doc, _ = schema.JSONSchema("update")
+	if got := schemaJSON(t, doc["allOf"].([]interface{})[1]); got != `{"oneOf":[{"required":["emailAddress"]},{"required":["phone"]}]}` {
+		t.Errorf("Unexpected oneOf %s", got)
+	}
+}
+
+func TestJSONSchemaConditions(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("payment_method").String().As("paymentMethod")
+	_ = schema.Optional("coupon").String()
+	schema.Given("payment_method", Equals("card"), func(p *Params) {
+		_ = p.Requires("card_number").On("create").String()
+		_ = p.Optional("card_type").String()
+		p.Given("card_type", Equals("corporate", "business"), func(p *Params) {
+			_ = p.Requires("company").On("create").String()
+		})
+	})
+	schema.Given("coupon", nil, func(p *Params) {
+		_ = p.Requires("campaign").On("create").String()
+	})
+
+	doc, warnings := schema.JSONSchema("create")
+	if len(warnings) != 0 {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+	want := `[{"if":{"properties":{"paymentMethod":{"const":"card"}},"required":["paymentMethod"]},` +
+		`"then":{"allOf":[{"if":{"properties":{"card_type":{"enum":["corporate","business"]}},"required":["card_type"]},"then":{"required":["company"]}}],` +
+		`"required":["card_number"]}},` +
+		`{"if":{"required":["coupon"]},"then":{"required":["campaign"]}}]`
+	if got := schemaJSON(t, doc["allOf"]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if _, ok := doc["properties"].(map[string]interface{})["company"]; !ok {
+		t.Error("Expected nested block fields as properties")
+	}
+
+	// Nothing is required outside create
+	doc, _ = schema.JSONSchema("update")
+	if doc["allOf"] != nil {
+		t.Errorf("Expected no rules, got %v", doc["allOf"])
+	}
+}
+
+func TestJSONSchemaNamesAndNulls(t *testing.T) {
+	schema := NewParams().UnknownKeys(RejectUnknown)
+	_ = schema.Requires("first_name").On("").String().As("firstName", "fname")
+	_ = schema.Optional("bio").String().Nullable()
+
+	doc, _ := schema.JSONSchema("")
+	want := `{"$schema":"` + SchemaDialect + `","additionalProperties":false,"properties":{` +
+		`"bio":{"type":["string","null"]},` +
+		`"firstName":{"type":"string"},` +
+		`"fname":{"$ref":"#/properties/firstName","deprecated":true}},` +
+		`"required":["firstName"],"type":"object"}`
+	if got := schemaJSON(t, doc); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestRegexValidator(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("slug").String().Validate("regex=^[a-z]+(-[a-z]+)*$")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"slug": "hello-world"}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"slug": "Hello World"}`), ""); err == nil {
+		t.Error("Expected regex error")
+	}
+
+	expectDefinitionPanic(t, "invalid regex", func() { _ = NewParams().Optional("v").String().Validate("regex=[a-") })
+	expectDefinitionPanic(t, "invalid element regex", func() {
+		_ = NewParams().Optional("v").SliceOf(String, nil).ElemValidate("min=1,regex=(")
+	})
+}
@@ -0,0 +1,136 @@
+package grape
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ElemValidate sets a validator tag applied to every element of a slice or
+// value of a map, e.g. "min=1" for SliceOf(Integer, nil) or "email" for
+// SliceOf(String, nil).
+func (f *FieldBuilder) ElemValidate(tag string) *FieldBuilder {
+	f.param.ElemValidate = tag
+	f.updateParent()
+	return f
+}
+
+// MinItems requires a slice to have at least n elements.
+func (f *FieldBuilder) MinItems(n int) *FieldBuilder {
+	f.param.MinItems = n
+	f.updateParent()
+	return f
+}
+
+// MaxItems limits a slice to n elements.
+func (f *FieldBuilder) MaxItems(n int) *FieldBuilder {
+	f.param.MaxItems = &n
+	f.updateParent()
+	return f
+}
+
+// Unique rejects slices with equal elements, compared after binding.
+func (f *FieldBuilder) Unique() *FieldBuilder {
+	f.param.Unique = true
+	f.updateParent()
+	return f
+}
+
+// elemParam returns the field each element of the slice f is bound as.
+func (f Param) elemParam() Param {
+	return Param{
+		Name:       f.Name,
+		Type:       f.SliceType,
+		Validate:   f.ElemValidate,
+		Schema:     f.Schema,
+		MaxSize:    f.MaxSize,
+		Extensions: f.Extensions,
+		MIMETypes:  f.MIMETypes,
+		Precision:  f.Precision,
+		Scale:      f.Scale,
+		Layouts:    f.Layouts,
+		Location:   f.Location,
+	}
+}
+
+// sliceType accepts arrays and binds every element as the field's
+// SliceType, reporting failures at the element's index.
+type sliceType struct{}
+
+func (sliceType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	svals, ok := val.([]interface{})
+	if !ok {
+		b.Mismatch(val, string(Slice))
+		return nil, false
+	}
+	ep := b.elemParam()
+	arr := make([]interface{}, 0, len(svals))
+	failed := false
+	for idx, elem := range svals {
+		eb := &Binding{Param: ep, Path: pointer(b.Path, strconv.Itoa(idx)), Mode: b.Mode, params: b.params, errs: b.errs, inList: true}
+		v, ok := eb.bindWith(ep.Type, ep.decoded(elem))
+		if !ok {
+			failed = true
+			continue
+		}
+		arr = append(arr, v)
+	}
+	return arr, !failed
+}
+
+func (sliceType) Validate(b *Binding, val interface{}) bool {
+	arr := val.([]interface{})
+	if !b.ValidateTag(arr) {
+		return false
+	}
+	if len(arr) < b.MinItems {
+		b.Fail(CodeValidationFailed+":min_items", "min_items", len(arr), MsgMinItems, strconv.Itoa(b.MinItems))
+		return false
+	}
+	if b.MaxItems != nil && len(arr) > *b.MaxItems {
+		b.Fail(CodeValidationFailed+":max_items", "max_items", len(arr), MsgMaxItems, strconv.Itoa(*b.MaxItems))
+		return false
+	}
+	if b.Unique {
+		seen := make(map[string]bool, len(arr))
+		ok := true
+		for idx, v := range arr {
+			key := uniqueKey(v)
+			if seen[key] {
+				eb := *b
+				eb.Path = pointer(b.Path, strconv.Itoa(idx))
+				eb.Fail(CodeValidationFailed+":unique", "unique", v, MsgUnique)
+				ok = false
+			}
+			seen[key] = true
+		}
+		return ok
+	}
+	return true
+}
+
+func (sliceType) Describe(f Param) map[string]interface{} {
+	s := map[string]interface{}{"type": "array"}
+	if f.SliceType != "" {
+		s["items"] = describeType(f.elemParam())
+	}
+	if f.MinItems > 0 {
+		s["minItems"] = f.MinItems
+	}
+	if f.MaxItems != nil {
+		s["maxItems"] = *f.MaxItems
+	}
+	if f.Unique {
+		s["uniqueItems"] = true
+	}
+	return s
+}
+
+// uniqueKey returns the JSON encoding of v, which is equal for elements
+// clients would consider duplicates.
+func uniqueKey(v interface{}) string {
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+	return fmt.Sprintf("%T:%v", v, v)
+}
@@ -0,0 +1,237 @@
+// Package grape provides tests for slice.go functionality.
+//
+// Test Functions:
+// - TestSliceElementTypes: Tests element coercion and type checks for each element type
+// - TestSliceElementErrors: Tests per-index paths, codes and messages of element errors
+// - TestSliceElemValidate: Tests validator tags applied to every element
+// - TestSliceItemCounts: Tests MinItems and MaxItems
+// - TestSliceUnique: Tests rejecting duplicate elements at their index
+// - TestSliceQuery: Tests element coercion of repeated query parameters
+// - TestSliceDefault: Tests Go slice defaults and their definition-time check
+// - TestSliceDescribe: Tests the JSON Schema of slice fields
+// - TestSliceValidateTag: Tests the field's own validator tag on slices, maps and JSON values, and tags that don't fit
+package grape
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSliceElementTypes(t *testing.T) {
+	tests := []struct {
+		typ  FieldType
+		body string
+		want []interface{}
+	}{
+		{String, `{"v": ["a", "b"]}`, []interface{}{"a", "b"}},
+		{Integer, `{"v": [1, 2]}`, []interface{}{1, 2}},
+		{Int64, `{"v": [9007199254740993]}`, []interface{}{int64(9007199254740993)}},
+		{Float, `{"v": [1.5]}`, []interface{}{1.5}},
+		{Boolean, `{"v": [true, false]}`, []interface{}{true, false}},
+		{"", `{"v": [1, "a"]}`, []interface{}{1.0, "a"}},
+	}
+	for _, tt := range tests {
+		schema := NewParams()
+		_ = schema.Requires("v").SliceOf(tt.typ, nil)
+
+		result, err := schema.BindAndValidateReader(strings.NewReader(tt.body), "")
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.typ, err)
+			continue
+		}
+		if got := result["v"]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %#v, got %#v", tt.typ, tt.want, got)
+		}
+	}
+
+	schema := NewParams()
+	_ = schema.Requires("v").SliceOf(Integer, nil)
+	for _, body := range []string{`{"v": ["1"]}`, `{"v": [1.5]}`, `{"v": [null]}`} {
+		if _, err := schema.BindAndValidate(createTestJSON(body), ""); err == nil {
+			t.Errorf("%s: expected element type error", body)
+		}
+	}
+}
+
+func TestSliceElementErrors(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("ids").SliceOf(Integer, nil)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"ids": [1, "x", 3, 4.5]}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected two element errors, got %v", err)
+	}
+	if verrs[0].Path != "/ids/1" || verrs[1].Path != "/ids/3" {
+		t.Errorf("Expected paths /ids/1 and /ids/3, got %q and %q", verrs[0].Path, verrs[1].Path)
+	}
+	if verrs[0].Code != CodeTypeMismatch || verrs[0].Field != "ids" {
+		t.Errorf("Unexpected error %+v", verrs[0])
+	}
+	if verrs[0].Message != "element in 'ids' must be integer" {
+		t.Errorf("Unexpected message %q", verrs[0].Message)
+	}
+}
+
+func TestSliceElemValidate(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("emails").SliceOf(String, nil).ElemValidate("email")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"emails": ["a@b.c", "d@e.f"]}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err := schema.BindAndValidate(createTestJSON(`{"emails": ["a@b.c", "nope"]}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 || verrs[0].Path != "/emails/1" || verrs[0].Rule != "email" {
+		t.Errorf("Expected email error at /emails/1, got %v", err)
+	}
+}
+
+func TestSliceItemCounts(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("tags").SliceOf(String, nil).MinItems(1).MaxItems(2)
+
+	tests := []struct {
+		body string
+		code string
+	}{
+		{`{"tags": []}`, CodeValidationFailed + ":min_items"},
+		{`{"tags": ["a"]}`, ""},
+		{`{"tags": ["a", "b"]}`, ""},
+		{`{"tags": ["a", "b", "c"]}`, CodeValidationFailed + ":max_items"},
+	}
+	for _, tt := range tests {
+		_, err := schema.BindAndValidate(createTestJSON(tt.body), "")
+		if tt.code == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.body, err)
+			}
+			continue
+		}
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || verrs[0].Code != tt.code || verrs[0].Path != "/tags" {
+			t.Errorf("%s: expected %s at /tags, got %v", tt.body, tt.code, err)
+		}
+	}
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"tags": []}`), "")
+	if msg := err.(ValidationErrors)[0].Message; msg != "field 'tags' must have at least 1 items" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func TestSliceUnique(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("ids").SliceOf(Integer, nil).Unique()
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"ids": [1, 2, 3]}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	_, err := schema.BindAndValidate(createTestJSON(`{"ids": [1, 2, 1, 2]}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected two duplicate errors, got %v", err)
+	}
+	if verrs[0].Path != "/ids/2" || verrs[1].Path != "/ids/3" || verrs[0].Code != CodeValidationFailed+":unique" {
+		t.Errorf("Unexpected errors %v", verrs)
+	}
+
+	// Elements are compared after binding
+	objects := NewParams()
+	item := NewParams().UnknownKeys(StripUnknown)
+	_ = item.Requires("sku").String()
+	_ = objects.Requires("items").SliceOf(JSON, item).Unique()
+	if _, err := objects.BindAndValidate(createTestJSON(`{"items": [{"sku": "a", "x": 1}, {"sku": "a"}]}`), ""); err == nil {
+		t.Error("Expected duplicate objects to be rejected")
+	}
+}
+
+func TestSliceQuery(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("ids").SliceOf(Integer, nil).ElemValidate("min=1")
+
+	result, err := schema.BindQuery(url.Values{"ids": {"1", "2"}}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result["ids"], []interface{}{1, 2}) {
+		t.Errorf("Expected [1 2], got %#v", result["ids"])
+	}
+
+	_, err = schema.BindQuery(url.Values{"ids": {"1", "0", "x"}}, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 || verrs[0].Path != "/ids/1" || verrs[1].Path != "/ids/2" {
+		t.Errorf("Expected errors at /ids/1 and /ids/2, got %v", err)
+	}
+}
+
+func TestSliceDefault(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("tags").SliceOf(String, nil).Default([]string{"new"})
+	_ = schema.Optional("ids").SliceOf(Integer, nil).Default([]int{1, 2})
+
+	result, err := schema.BindAndValidate(createTestJSON(`{}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result["tags"], []interface{}{"new"}) || !reflect.DeepEqual(result["ids"], []interface{}{1, 2}) {
+		t.Errorf("Unexpected defaults %#v %#v", result["tags"], result["ids"])
+	}
+
+	expectDefinitionPanic(t, "element type", func() {
+		_ = NewParams().Optional("ids").SliceOf(Integer, nil).Default([]string{"x"})
+	})
+	expectDefinitionPanic(t, "max items", func() {
+		_ = NewParams().Optional("ids").SliceOf(Integer, nil).MaxItems(1).Default([]int{1, 2})
+	})
+}
+
+func TestSliceDescribe(t *testing.T) {
+	schema := NewParams()
+	f := schema.Optional("ids").SliceOf(Integer, nil).MinItems(1).MaxItems(5).Unique()
+
+	want := map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "integer"},
+		"minItems":    1,
+		"maxItems":    5,
+		"uniqueItems": true,
+	}
+	if got := describeType(f.param); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestSliceValidateTag(t *testing.T) {
+	item := NewParams()
+	_ = item.Optional("sku").String()
+	schema := NewParams()
+	_ = schema.Optional("tags").SliceOf(String, nil).Validate("min=2")
+	_ = schema.Optional("labels").MapOf(KeyRule{}, String, nil).Validate("min=1")
+	_ = schema.Optional("item").JSON().WithSchema(item).Validate("min=1")
+	_ = schema.Optional("raw").JSON().Validate("max=1")
+
+	if _, err := schema.BindAndValidate(createTestJSON(`{"tags": ["a", "b"], "labels": {"env": "prod"}, "item": {"sku": "x"}, "raw": [1]}`), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, body := range []string{`{"tags": []}`, `{"labels": {}}`, `{"item": {}}`, `{"raw": [1, 2]}`} {
+		_, err := schema.BindAndValidate(createTestJSON(body), "")
+		verrs, ok := err.(ValidationErrors)
+		if !ok || len(verrs) != 1 || !strings.HasPrefix(verrs[0].Code, CodeValidationFailed+":") {
+			t.Errorf("%s: expected a validation error, got %v", body, err)
+		}
+	}
+
+	expectDefinitionPanic(t, "slice oneof", func() { _ = NewParams().Optional("v").SliceOf(String, nil).Validate("oneof=a b") })
+	expectDefinitionPanic(t, "map oneof", func() { _ = NewParams().Optional("v").MapOf(KeyRule{}, String, nil).Validate("oneof=a b") })
+
+	// Rules that only fail to fit some values are failures, not panics
+	loose := NewParams()
+	_ = loose.Optional("v").SliceOf("", nil).Validate("dive,min=1")
+	_, err := loose.BindAndValidate(createTestJSON(`{"v": [true]}`), "")
+	if verrs, ok := err.(ValidationErrors); !ok || len(verrs) != 1 || verrs[0].Code != CodeValidationFailed+":dive,min=1" {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package grape
+
+import (
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	structParams   sync.Map // reflect.Type -> *Params
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// ParamsFromStruct builds the Params equivalent to the struct v or *v,
+// such as a request DTO, so the two can't drift apart:
+//
+//	type CreateUser struct {
+//		Name    string    `grape:"name,required=create|update,validate=min=2,max=100"`
+//		Email   string    `json:"email" grape:",required=create,validate=email"`
+//		Age     *int      `grape:"age,nullable"`
+//		Role    string    `grape:"role,default=member"`
+//		Address Address   // nested schema
+//		Tags    []string  // SliceOf(String, nil)
+//		Born    time.Time `grape:"born,type=date"`
+//	}
+//
+//	schema := grape.ParamsFromStruct(CreateUser{})
+//
+// Field names come from the `grape` tag, then the `json` tag, then the
+// snake_case Go name, as in Input.ToModelE; "-" skips a field and
+// embedded structs are flattened. The options of the `grape` tag are:
+//
+//   - required: required in the modes separated by "|", or in every mode
+//     when none are given
+//   - type=<FieldType>: a type to use instead of the one derived from the
+//     Go type, e.g. date or a type registered with RegisterType; for
+//     slices and maps it is the element type
+//   - default=<value>: the default, converted like a query string value
+//   - nullable: accept an explicit null; implied by sql.Null types
+//   - validate=<tag>: the validator tag, which may contain commas and
+//     must therefore come last; a `validate` struct tag works as well
+//
+// Go types map to String, Integer, Int64, Uint, Float, Boolean, DateTime
+// (time.Time), BigDecimal (Decimal, big.Rat), File (*multipart.FileHeader)
+// and JSON (nested structs, with their schema, and interface{}). Slices
+// and maps with string keys become SliceOf and MapOf fields. Types
+// implementing encoding.TextUnmarshaler, such as UUIDs, are Strings.
+// Unsupported types and options panic.
+//
+// The Params of each type are built once and cached; every call returns a
+// copy that can be changed without affecting later calls.
+func ParamsFromStruct(v interface{}) *Params {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("grape: ParamsFromStruct needs a struct, got %T", v))
+	}
+	if p, ok := structParams.Load(t); ok {
+		return cloneSchema(p.(*Params), map[*Params]*Params{})
+	}
+	p, _ := structParams.LoadOrStore(t, structSchema(t, map[reflect.Type]*Params{}))
+	return cloneSchema(p.(*Params), map[*Params]*Params{})
+}
+
+// cloneSchema copies p along with the schemas nested in its fields and
+// conditions. copies maps the schemas copied so far to their copies, so
+// recursive schemas stay recursive.
+func cloneSchema(p *Params, copies map[*Params]*Params) *Params {
+	if cp, ok := copies[p]; ok {
+		return cp
+	}
+	cp := p.clone()
+	copies[p] = cp
+	for i, f := range cp.Fields {
+		if f.Schema != nil {
+			cp.Fields[i].Schema = cloneSchema(f.Schema, copies)
+		}
+	}
+	for i, c := range cp.Conditions {
+		cp.Conditions[i].Schema = cloneSchema(c.Schema, copies)
+	}
+	return cp
+}
+
+// structSchema builds the Params of the struct type t. building holds the
+// types being built so recursive types refer to their own schema.
+func structSchema(t reflect.Type, building map[reflect.Type]*Params) *Params {
+	if p, ok := building[t]; ok {
+		return p
+	}
+	p := NewParams()
+	building[t] = p
+	addStructFields(p, t, building)
+	return p
+}
+
+// addStructFields declares a field of p for every field of the struct t.
+func addStructFields(p *Params, t reflect.Type, building map[reflect.Type]*Params) {
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		if et, ok := embeddedStruct(sf); ok {
+			addStructFields(p, et, building)
+			continue
+		}
+		name, ok := modelKey(sf)
+		if !ok || !sf.IsExported() {
+			continue
+		}
+		addStructField(p, name, sf, building)
+	}
+}
+
+// structTag holds the options of a `grape` struct tag.
+type structTag struct {
+	required   bool
+	modes      []string
+	typ        FieldType
+	def        string
+	hasDefault bool
+	nullable   bool
+	validate   string
+}
+
+// parseStructTag parses the options of the `grape` tag of sf.
+func parseStructTag(sf reflect.StructField) structTag {
+	tag := structTag{validate: sf.Tag.Get("validate")}
+	parts := strings.Split(sf.Tag.Get("grape"), ",")
+	for i := 1; i < len(parts); i++ {
+		key, val, _ := strings.Cut(parts[i], "=")
+		switch key {
+		case "required":
+			tag.required = true
+			if val != "" {
+				tag.modes = strings.Split(val, "|")
+			}
+		case "type":
+			tag.typ = FieldType(val)
+		case "default":
+			tag.def, tag.hasDefault = val, true
+		case "nullable":
+			tag.nullable = true
+		case "validate":
+			tag.validate = strings.Join(append([]string{val}, parts[i+1:]...), ",")
+			return tag
+		default:
+			panic(fmt.Sprintf("grape: unknown option '%s' for field '%s'", parts[i], sf.Name))
+		}
+	}
+	return tag
+}
+
+// addStructField declares the field name of p for the struct field sf.
+func addStructField(p *Params, name string, sf reflect.StructField, building map[reflect.Type]*Params) {
+	tag := parseStructTag(sf)
+
+	var fb *FieldBuilder
+	if tag.required {
+		if len(tag.modes) == 0 {
+			tag.modes = []string{AllModes}
+		}
+		fb = p.Requires(name).On(tag.modes...)
+	} else {
+		fb = p.Optional(name)
+	}
+
+	t := derefType(sf.Type)
+	if vt, ok := nullType(t); ok {
+		t = vt
+		tag.nullable = true
+	}
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		et, schema := goFieldType(derefType(t.Elem()), building)
+		fb.SliceOf(tag.typeOr(et, sf), schema)
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		et, schema := goFieldType(derefType(t.Elem()), building)
+		fb.MapOf(KeyRule{}, tag.typeOr(et, sf), schema)
+	default:
+		ft, schema := goFieldType(t, building)
+		fb.Type(tag.typeOr(ft, sf))
+		if schema != nil {
+			fb.WithSchema(schema)
+		}
+	}
+
+	if tag.validate != "" {
+		fb.Validate(tag.validate)
+	}
+	if tag.nullable {
+		fb.Nullable()
+	}
+	if tag.hasDefault {
+		var def interface{} = tag.def
+		if c, ok := typeHandlers[fb.param.Type].(StringCoercer); ok {
+			def = c.CoerceString(tag.def)
+		}
+		fb.Default(def)
+	}
+}
+
+// typeOr returns the type option of the tag, or derived when it has none.
+// Neither being set means sf's Go type is not supported.
+func (tag structTag) typeOr(derived FieldType, sf reflect.StructField) FieldType {
+	if tag.typ != "" {
+		return tag.typ
+	}
+	if derived == "" {
+		panic(fmt.Sprintf("grape: unsupported type %s of field '%s'", sf.Type, sf.Name))
+	}
+	return derived
+}
+
+// goFieldType returns the FieldType of values of the Go type t and, for
+// structs, their schema. It returns "" for unsupported types.
+func goFieldType(t reflect.Type, building map[reflect.Type]*Params) (FieldType, *Params) {
+	switch t {
+	case timeGoType:
+		return DateTime, nil
+	case decimalGoType, ratGoType:
+		return BigDecimal, nil
+	case fileHeaderType:
+		return File, nil
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return String, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return String, nil
+	case reflect.Bool:
+		return Boolean, nil
+	case reflect.Int64:
+		return Int64, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return Integer, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Uint, nil
+	case reflect.Float32, reflect.Float64:
+		return Float, nil
+	case reflect.Interface:
+		return JSON, nil
+	case reflect.Struct:
+		return JSON, structSchema(t, building)
+	}
+	return "", nil
+}
+
+// nullType returns the value type of sql.Null types such as
+// sql.NullString and sql.Null[T]: sql.Scanner structs of a value and a
+// Valid flag.
+func nullType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 || !reflect.PointerTo(t).Implements(scannerType) {
+		return nil, false
+	}
+	if valid := t.Field(1); valid.Name != "Valid" || valid.Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	return t.Field(0).Type, true
+}
+
+// derefType returns the type t points to, through any number of pointers.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
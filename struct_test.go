@@ -0,0 +1,232 @@
+// Package grape provides tests for struct.go functionality.
+//
+// Test Functions:
+// - TestParamsFromStructTypes: Tests field types derived from Go types
+// - TestParamsFromStructTags: Tests names, required modes, validate, default and nullable options
+// - TestParamsFromStructNested: Tests nested structs, slices of structs and recursive types
+// - TestParamsFromStructBind: Tests binding with a derived schema and mapping back with ToModelE
+// - TestParamsFromStructCache: Tests that the cached schema is shared but copies are independent
+// - TestParamsFromStructInvalid: Tests the definition-time checks
+package grape
+
+import (
+	"database/sql"
+	"mime/multipart"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type structAddress struct {
+	City string `grape:"city,required"`
+	Zip  string `json:"zip_code"`
+}
+
+type structNode struct {
+	Name     string
+	Children []structNode
+}
+
+func TestParamsFromStructTypes(t *testing.T) {
+	type Audit struct {
+		CreatedBy string
+	}
+	type DTO struct {
+		Audit
+		Name     string
+		Count    int
+		ID       int64
+		Size     uint16
+		Ratio    float64
+		Active   *bool
+		At       time.Time
+		Price    Decimal
+		Avatar   *multipart.FileHeader
+		IP       net.IP
+		Extra    interface{}
+		Tags     []string
+		Docs     []*multipart.FileHeader
+		Labels   map[string]string
+		Nickname sql.NullString
+		private  string
+	}
+	schema := ParamsFromStruct(&DTO{})
+
+	want := map[string]FieldType{
+		"created_by": String, "name": String, "count": Integer, "id": Int64, "size": Uint,
+		"ratio": Float, "active": Boolean, "at": DateTime, "price": BigDecimal, "avatar": File,
+		"ip": String, "extra": JSON, "tags": Slice, "docs": Slice, "labels": Map, "nickname": String,
+	}
+	if len(schema.Fields) != len(want) {
+		t.Errorf("Expected %d fields, got %d", len(want), len(schema.Fields))
+	}
+	for _, f := range schema.Fields {
+		if want[f.Name] != f.Type {
+			t.Errorf("%s: expected %s, got %s", f.Name, want[f.Name], f.Type)
+		}
+	}
+	tags, _ := schema.field("tags")
+	docs, _ := schema.field("docs")
+	labels, _ := schema.field("labels")
+	nickname, _ := schema.field("nickname")
+	if tags.SliceType != String || docs.SliceType != File || labels.ValueType != String || !nickname.Nullable {
+		t.Errorf("Unexpected element types %s %s %s or nullable %v", tags.SliceType, docs.SliceType, labels.ValueType, nickname.Nullable)
+	}
+}
+
+func TestParamsFromStructTags(t *testing.T) {
+	type DTO struct {
+		Name   string    `grape:"full_name,required=create|update,validate=min=2,max=10"`
+		Email  string    `json:"email_address" grape:",required,validate=email"`
+		Age    *int      `grape:"age,nullable"`
+		Role   string    `grape:"role,default=member"`
+		Limit  int       `grape:"limit,default=20" validate:"max=100"`
+		Born   time.Time `grape:"born,type=date"`
+		Secret string    `json:"-"`
+	}
+	schema := ParamsFromStruct(DTO{})
+
+	name, ok := schema.field("full_name")
+	if !ok || !reflect.DeepEqual(name.RequiredOn, []string{"create", "update"}) || name.Validate != "min=2,max=10" {
+		t.Errorf("Unexpected full_name %+v", name)
+	}
+	email, ok := schema.field("email_address")
+	if !ok || !reflect.DeepEqual(email.RequiredOn, []string{AllModes}) || email.Validate != "email" {
+		t.Errorf("Unexpected email_address %+v", email)
+	}
+	if age, _ := schema.field("age"); !age.Nullable || age.Type != Integer {
+		t.Errorf("Unexpected age %+v", age)
+	}
+	if born, _ := schema.field("born"); born.Type != Date {
+		t.Errorf("Expected date, got %s", born.Type)
+	}
+	if _, ok := schema.field("secret"); ok {
+		t.Error("Expected secret to be skipped")
+	}
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"email_address": "a@b.c", "age": null}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.String("role") != "member" || input.Integer("limit", 0) != 20 || !input.IsNull("age") {
+		t.Errorf("Unexpected input %v", input)
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"email_address": "a@b.c"}`), "create"); err == nil {
+		t.Error("Expected full_name to be required for create")
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"full_name": "Ada"}`), "create"); err == nil {
+		t.Error("Expected email_address to be required in every mode")
+	}
+	if _, err := schema.BindAndValidate(createTestJSON(`{"email_address": "a@b.c", "limit": 500}`), ""); err == nil {
+		t.Error("Expected the validate struct tag to apply")
+	}
+}
+
+func TestParamsFromStructNested(t *testing.T) {
+	type DTO struct {
+		Shipping  structAddress
+		Billing   *structAddress
+		Addresses []structAddress
+		Tree      structNode
+	}
+	schema := ParamsFromStruct(DTO{})
+
+	_, err := schema.BindAndValidate(createTestJSON(`{
+		"shipping": {"city": "Oslo"},
+		"billing": {"zip_code": "0150"},
+		"addresses": [{"city": "Bergen"}, {"city": 5}],
+		"tree": {"name": "root", "children": [{"name": "leaf", "children": [{"name": 1}]}]}
+	}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	var paths []string
+	for _, e := range verrs {
+		paths = append(paths, e.Path)
+	}
+	want := []string{"/billing/city", "/addresses/1/city", "/tree/children/0/children/0/name"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected errors at %v, got %v", want, paths)
+	}
+}
+
+func TestParamsFromStructBind(t *testing.T) {
+	type Line struct {
+		SKU   string  `grape:"sku,required"`
+		Price Decimal `grape:"price,required"`
+	}
+	type Order struct {
+		PlacedOn time.Time `grape:"placed_on,required,type=date"`
+		Lines    []Line    `grape:"lines,required"`
+		Note     *string
+	}
+	schema := ParamsFromStruct(Order{})
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"placed_on": "2024-03-01", "lines": [{"sku": "a", "price": "9.99"}], "note": "hi"}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var order Order
+	if err := input.ToModelE(&order); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order.PlacedOn.Month() != time.March || len(order.Lines) != 1 || order.Lines[0].Price.String() != "9.99" || *order.Note != "hi" {
+		t.Errorf("Unexpected order %+v", order)
+	}
+}
+
+func TestParamsFromStructCache(t *testing.T) {
+	type DTO struct {
+		Name string
+	}
+	a := ParamsFromStruct(DTO{})
+	_ = a.Optional("extra").String()
+	b := ParamsFromStruct(&DTO{})
+
+	if len(b.Fields) != 1 {
+		t.Errorf("Expected 1 field, got %d", len(b.Fields))
+	}
+	cached, ok := structParams.Load(reflect.TypeOf(DTO{}))
+	if !ok || len(cached.(*Params).Fields) != 1 {
+		t.Error("Expected the schema to be cached unchanged")
+	}
+
+	// Nested schemas are copied too, and recursive ones stay recursive
+	node := ParamsFromStruct(structNode{})
+	children := node.Fields[1].Schema
+	_ = children.Optional("extra").String()
+	if children != children.Fields[1].Schema {
+		t.Error("Expected the copy to stay recursive")
+	}
+	if again := ParamsFromStruct(structNode{}); len(again.Fields[1].Schema.Fields) != 2 {
+		t.Errorf("Expected the nested schema to be unchanged, got %+v", again.Fields[1].Schema.Fields)
+	}
+}
+
+func TestParamsFromStructInvalid(t *testing.T) {
+	type BadType struct {
+		C chan int
+	}
+	type BadOption struct {
+		Name string `grape:"name,requird"`
+	}
+	type BadDefault struct {
+		Count int `grape:"count,default=many"`
+	}
+	type BadCustomType struct {
+		ID string `grape:"id,type=no_such_type"`
+	}
+	tests := map[string]interface{}{
+		"not a struct":  5,
+		"type":          BadType{},
+		"option":        BadOption{},
+		"default":       BadDefault{},
+		"custom type":   BadCustomType{},
+		"nil interface": nil,
+	}
+	for name, v := range tests {
+		expectDefinitionPanic(t, name, func() { ParamsFromStruct(v) })
+	}
+}
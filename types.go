@@ -0,0 +1,305 @@
+package grape
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TypeHandler binds the values of one FieldType. The built-in types are
+// handlers too; RegisterType adds new ones such as UUID or Money.
+type TypeHandler interface {
+	// Coerce converts a submitted value into the Go value bound for the
+	// field. It records a failure on b, usually b.Mismatch, and returns
+	// false when the value cannot be converted.
+	Coerce(b *Binding, val interface{}) (interface{}, bool)
+	// Validate applies the field's rules, such as b.ValidateTag and
+	// b.CheckValues, to the coerced value and reports whether it passed.
+	Validate(b *Binding, val interface{}) bool
+	// Describe returns the JSON Schema of a field of the type.
+	Describe(f Param) map[string]interface{}
+}
+
+// StringCoercer is implemented by TypeHandlers that convert the strings
+// submitted through BindQuery, BindForm and BindMultipart. It returns s
+// unchanged when it cannot convert it. Types without it receive strings.
+type StringCoercer interface {
+	CoerceString(s string) interface{}
+}
+
+var typeHandlers = map[FieldType]TypeHandler{}
+
+// RegisterType makes t usable with FieldBuilder.Type and SliceOf. Like
+// RegisterCatalog it should be called during initialization. Registering
+// a type twice panics. Type mismatch messages describe t with the catalog
+// key "type.<t>", falling back to t itself.
+func RegisterType(t FieldType, h TypeHandler) {
+	if t == "" || h == nil {
+		panic("grape: RegisterType needs a type name and a handler")
+	}
+	if _, ok := typeHandlers[t]; ok {
+		panic(fmt.Sprintf("grape: type '%s' is already registered", t))
+	}
+	typeHandlers[t] = h
+}
+
+func init() {
+	RegisterType(String, stringType{})
+	RegisterType(Integer, integerType{})
+	RegisterType(Int64, integerType{})
+	RegisterType(Uint, integerType{})
+	RegisterType(Float, floatType{})
+	RegisterType(BigDecimal, decimalType{})
+	RegisterType(Numeric, numericType{})
+	RegisterType(Date, timeType{})
+	RegisterType(DateTime, timeType{})
+	RegisterType(Time, timeType{})
+	RegisterType(Boolean, booleanType{})
+	RegisterType(JSON, jsonType{})
+	RegisterType(Slice, sliceType{})
+	RegisterType(Map, mapType{})
+	RegisterType(File, fileType{})
+}
+
+// Type sets a field type registered with RegisterType. Unregistered types
+// panic.
+func (f *FieldBuilder) Type(t FieldType) *FieldBuilder {
+	checkType(t, f.param.Name)
+	f.param.Type = t
+	f.updateParent()
+	return f
+}
+
+// checkType panics when no handler is registered for t, the type of
+// field name or of its elements.
+func checkType(t FieldType, name string) {
+	if _, ok := typeHandlers[t]; !ok {
+		panic(fmt.Sprintf("grape: unknown type '%s' for field '%s'", t, name))
+	}
+}
+
+// Binding is the context a TypeHandler binds one value in.
+type Binding struct {
+	Param         // the declared field
+	Path   string // RFC 6901 JSON pointer of the value
+	Mode   string // mode passed to the bind call
+	params *Params
+	errs   *ValidationErrors
+	inList bool // binding a slice element
+}
+
+// Fail records a failure of rule with the given code. key is a message
+// key; the field name is {0} and args are {1} onwards. The type of the
+// type mismatch messages defaults to rule.
+func (b *Binding) Fail(code, rule string, val interface{}, key string, args ...string) {
+	b.errs.add(code, b.Name, b.Path, rule, val, key, append([]string{b.Name}, args...)...)
+}
+
+// Mismatch records that val is not of type typ, described in messages by
+// the catalog key "type.<typ>".
+func (b *Binding) Mismatch(val interface{}, typ string) {
+	if b.inList {
+		b.Fail(CodeTypeMismatch, typ, val, MsgElementTypeMismatch, typ)
+		return
+	}
+	b.errs.mismatch(b.Name, b.Path, typ, val)
+}
+
+// ValidateTag applies the field's validator tag to val.
+func (b *Binding) ValidateTag(val interface{}) bool {
+	return b.errs.validateVar(b.Param, b.Path, val)
+}
+
+// CheckValues applies the field's allowed values to val.
+func (b *Binding) CheckValues(val interface{}) bool {
+	return b.errs.checkValues(b.Param, b.Path, val)
+}
+
+// object binds m against the field's schema and nests the errors at the
+// binding path.
+func (b *Binding) object(m map[string]interface{}) (map[string]interface{}, bool) {
+	nested, errs := b.Schema.within(b.params).bindObject(m, b.Mode)
+	if len(errs) > 0 {
+		b.errs.nest(b.Path, errs)
+		return nil, false
+	}
+	return map[string]interface{}(nested), true
+}
+
+// bindWith binds val with the handler of t, recording failures at b.
+func (b *Binding) bindWith(t FieldType, val interface{}) (interface{}, bool) {
+	h, ok := typeHandlers[t]
+	if !ok {
+		return plainNumbers(val), true
+	}
+	v, ok := h.Coerce(b, val)
+	if !ok || !h.Validate(b, v) {
+		return nil, false
+	}
+	return v, true
+}
+
+// describeType returns the JSON Schema of f, or an empty schema when its
+// type is not registered.
+func describeType(f Param) map[string]interface{} {
+	if h, ok := typeHandlers[f.Type]; ok {
+		return h.Describe(f)
+	}
+	return map[string]interface{}{}
+}
+
+type stringType struct{}
+
+func (stringType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	s, ok := val.(string)
+	if !ok {
+		b.Mismatch(val, string(String))
+	}
+	return s, ok
+}
+
+func (stringType) Validate(b *Binding, val interface{}) bool {
+	return b.ValidateTag(val) && b.CheckValues(val)
+}
+
+func (stringType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "string"}
+}
+
+type floatType struct{}
+
+func (floatType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	fv, ok := val.(float64)
+	if !ok {
+		b.Mismatch(val, string(Float))
+	}
+	return fv, ok
+}
+
+func (floatType) Validate(b *Binding, val interface{}) bool {
+	return b.ValidateTag(val) && b.CheckValues(val)
+}
+
+func (floatType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "number"}
+}
+
+func (floatType) CoerceString(s string) interface{} {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// numericType is similar to Float but accepts both float and string.
+type numericType struct{}
+
+func (numericType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	switch val.(type) {
+	case float64, string:
+		return val, true
+	}
+	b.Mismatch(val, string(Numeric))
+	return nil, false
+}
+
+func (numericType) Validate(b *Binding, val interface{}) bool {
+	return b.ValidateTag(val)
+}
+
+func (numericType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": []interface{}{"number", "string"}}
+}
+
+func (numericType) CoerceString(s string) interface{} {
+	return floatType{}.CoerceString(s)
+}
+
+type booleanType struct{}
+
+func (booleanType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	bv, ok := val.(bool)
+	if !ok {
+		b.Mismatch(val, string(Boolean))
+	}
+	return bv, ok
+}
+
+func (booleanType) Validate(*Binding, interface{}) bool { return true }
+
+func (booleanType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean"}
+}
+
+func (booleanType) CoerceString(s string) interface{} {
+	switch strings.ToLower(s) {
+	case "on", "yes":
+		return true
+	case "off", "no":
+		return false
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+// jsonType accepts a map, a slice, or a string containing JSON. With a
+// schema the value must be an object, which is bound against the schema.
+// The field's validator tag applies to objects and arrays.
+type jsonType struct{}
+
+func (jsonType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	if s, ok := val.(string); ok {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+			b.Mismatch(val, "valid_json")
+			return nil, false
+		}
+		if b.Schema == nil {
+			return parsed, true
+		}
+		val = parsed
+	}
+	switch vv := val.(type) {
+	case map[string]interface{}:
+		if b.Schema != nil {
+			return b.object(vv)
+		}
+		return plainNumbers(vv), true
+	case []interface{}:
+		if b.Schema == nil {
+			return plainNumbers(vv), true
+		}
+	}
+	if b.Schema != nil {
+		b.Mismatch(val, "object")
+		return nil, false
+	}
+	b.Mismatch(val, string(JSON))
+	return nil, false
+}
+
+func (jsonType) Validate(b *Binding, val interface{}) bool {
+	switch val.(type) {
+	case map[string]interface{}, []interface{}:
+		return b.ValidateTag(val)
+	}
+	return true
+}
+
+func (jsonType) Describe(f Param) map[string]interface{} {
+	if f.Schema != nil {
+		return map[string]interface{}{"type": "object"}
+	}
+	return map[string]interface{}{"type": []interface{}{"object", "array", "string"}}
+}
+
+func (jsonType) CoerceString(s string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(s), &parsed); err == nil {
+		return parsed
+	}
+	return s
+}
@@ -0,0 +1,187 @@
+// Package grape provides tests for types.go functionality.
+//
+// Test Functions:
+// - TestRegisterTypeCustom: Tests binding a custom registered type
+// - TestRegisterTypeValidation: Tests custom failures, validator tags and localized type descriptions
+// - TestRegisterTypeStringCoercer: Tests query coercion through StringCoercer
+// - TestRegisterTypePanics: Tests definition-time panics for bad registrations and unknown types
+// - TestDescribeBuiltinTypes: Tests the JSON Schema of built-in types
+package grape
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+)
+
+const testUUID FieldType = "test_uuid"
+const testCents FieldType = "test_cents"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+type uuidType struct{}
+
+func (uuidType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	s, ok := val.(string)
+	if !ok || !uuidPattern.MatchString(strings.ToLower(s)) {
+		b.Mismatch(val, string(testUUID))
+		return nil, false
+	}
+	return strings.ToLower(s), true
+}
+
+func (uuidType) Validate(b *Binding, val interface{}) bool { return b.ValidateTag(val) }
+
+func (uuidType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "format": "uuid"}
+}
+
+// centsType binds amounts like "12.34" into integer cents.
+type centsType struct{}
+
+func (centsType) Coerce(b *Binding, val interface{}) (interface{}, bool) {
+	d, err := ParseDecimal(fmt.Sprint(val))
+	if err != nil || d.Scale() > 2 {
+		b.Mismatch(val, string(testCents))
+		return nil, false
+	}
+	cents, _ := strconv.ParseInt(strings.Replace(d.Rat().FloatString(2), ".", "", 1), 10, 64)
+	return cents, true
+}
+
+func (centsType) Validate(b *Binding, val interface{}) bool {
+	if val.(int64) < 0 {
+		b.Fail(CodeValidationFailed+":negative", "negative", val, MsgValidationFailed, "must not be negative")
+		return false
+	}
+	return true
+}
+
+func (centsType) Describe(Param) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "pattern": `^-?\d+(\.\d{1,2})?$`}
+}
+
+func (centsType) CoerceString(s string) interface{} { return s }
+
+func init() {
+	RegisterType(testUUID, uuidType{})
+	RegisterType(testCents, centsType{})
+}
+
+func TestRegisterTypeCustom(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Requires("id").On("").Type(testUUID)
+	_ = schema.Optional("price").Type(testCents)
+
+	raw := createTestJSON(`{"id": "0F8FAD5B-D9CB-469F-A165-70867728950E", "price": "12.3"}`)
+	input, err := schema.BindAndValidate(raw, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.String("id") != "0f8fad5b-d9cb-469f-a165-70867728950e" {
+		t.Errorf("Expected normalized uuid, got %v", input["id"])
+	}
+	if input.Int64("price", 0) != 1230 {
+		t.Errorf("Expected 1230 cents, got %v", input["price"])
+	}
+	if schema.Fields[0].Type != testUUID {
+		t.Errorf("Expected field type %s, got %s", testUUID, schema.Fields[0].Type)
+	}
+}
+
+func TestRegisterTypeValidation(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("id").Type(testUUID).Validate("excludes=0000")
+	_ = schema.Optional("price").Type(testCents)
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"id": "nope", "price": -1}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", err)
+	}
+	if verrs[0].Code != CodeTypeMismatch || verrs[0].Message != "field 'id' must be test_uuid" {
+		t.Errorf("Unexpected mismatch %+v", verrs[0])
+	}
+	if verrs[1].Code != "validation_failed:negative" || verrs[1].Message != "field 'price' validation failed: must not be negative" {
+		t.Errorf("Unexpected failure %+v", verrs[1])
+	}
+
+	_, err = schema.BindAndValidate(createTestJSON(`{"id": "00000000-0000-0000-0000-000000000000"}`), "")
+	if err == nil || err.(ValidationErrors)[0].Code != "validation_failed:excludes" {
+		t.Errorf("Expected validator tag to run, got %v", err)
+	}
+
+	if err := RegisterCatalog(Catalog{Locale: en.New(), Messages: map[string]string{"type.test_uuid": "a UUID"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = schema.BindAndValidate(createTestJSON(`{"id": 5}`), "")
+	if err == nil || err.Error() != "field 'id' must be a UUID" {
+		t.Errorf("Expected catalog type description, got %v", err)
+	}
+
+	// A mismatch without the type argument describes the rule
+	var errs ValidationErrors
+	b := &Binding{Param: Param{Name: "id"}, Path: "/id", errs: &errs}
+	b.Fail(CodeTypeMismatch, string(testUUID), 5, MsgTypeMismatch)
+	if err := errs.localize(""); err == nil || err.Error() != "field 'id' must be a UUID" {
+		t.Errorf("Expected the rule as the type, got %v", err)
+	}
+}
+
+func TestRegisterTypeStringCoercer(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("price").Type(testCents)
+	_ = schema.Optional("id").Type(testUUID)
+
+	input, err := schema.BindQuery(url.Values{"price": {"0.5"}, "id": {"0f8fad5b-d9cb-469f-a165-70867728950e"}}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input.Int64("price", 0) != 50 {
+		t.Errorf("Expected 50 cents, got %v", input["price"])
+	}
+}
+
+func TestRegisterTypePanics(t *testing.T) {
+	expectPanic := func(name string, fn func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+	expectPanic("duplicate", func() { RegisterType(String, uuidType{}) })
+	expectPanic("empty name", func() { RegisterType("", uuidType{}) })
+	expectPanic("nil handler", func() { RegisterType("other", nil) })
+	expectPanic("unknown type", func() { NewParams().Optional("x").Type("money") })
+	expectPanic("unknown element type", func() { NewParams().Optional("x").SliceOf("integr", nil) })
+	expectPanic("unknown value type", func() { NewParams().Optional("x").MapOf(KeyRule{}, "integr", nil) })
+}
+
+func TestDescribeBuiltinTypes(t *testing.T) {
+	tests := []struct {
+		param    Param
+		expected map[string]interface{}
+	}{
+		{Param{Type: String}, map[string]interface{}{"type": "string"}},
+		{Param{Type: Int64}, map[string]interface{}{"type": "integer", "format": "int64"}},
+		{Param{Type: Uint}, map[string]interface{}{"type": "integer", "minimum": 0}},
+		{Param{Type: Date}, map[string]interface{}{"type": "string", "format": "date"}},
+		{Param{Type: DateTime, Layouts: []string{"02.01.2006 15:04"}}, map[string]interface{}{"type": "string"}},
+		{Param{Type: Slice, SliceType: Boolean}, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "boolean"}}},
+		{Param{Type: testUUID}, map[string]interface{}{"type": "string", "format": "uuid"}},
+		{Param{}, map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		if got := describeType(tt.param); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.param.Type, tt.expected, got)
+		}
+	}
+}
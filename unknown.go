@@ -0,0 +1,82 @@
+package grape
+
+import "sort"
+
+// UnknownKeyPolicy decides what binding does with keys a schema does not
+// declare.
+type UnknownKeyPolicy int
+
+const (
+	// InheritUnknown uses the policy of the enclosing schema. At the top
+	// level it behaves like PassthroughUnknown. It is the default.
+	InheritUnknown UnknownKeyPolicy = iota
+	// PassthroughUnknown copies undeclared keys into the bound Input as is.
+	PassthroughUnknown
+	// StripUnknown drops undeclared keys.
+	StripUnknown
+	// RejectUnknown reports every undeclared key as an unknown_field error.
+	RejectUnknown
+)
+
+// UnknownKeys sets how p treats undeclared keys. Schemas nested with
+// WithSchema or SliceOf inherit the policy unless they set their own:
+//
+//	schema := grape.NewParams().UnknownKeys(grape.RejectUnknown)
+//
+// Passthrough lets clients set any struct field reachable through
+// Input.ToModel; use StripUnknown or RejectUnknown for such schemas.
+func (p *Params) UnknownKeys(policy UnknownKeyPolicy) *Params {
+	p.unknown = policy
+	return p
+}
+
+// unknownKeys returns the effective policy of p.
+func (p *Params) unknownKeys() UnknownKeyPolicy {
+	if p.unknown == InheritUnknown {
+		return PassthroughUnknown
+	}
+	return p.unknown
+}
+
+// within returns p as bound inside parent: a copy inheriting parent's
+// unknown-key policy when p has none of its own.
+func (p *Params) within(parent *Params) *Params {
+	if p.unknown != InheritUnknown {
+		return p
+	}
+	cp := *p
+	cp.unknown = parent.unknownKeys()
+	return &cp
+}
+
+// declares reports whether p has a field named name, including fields of
+// conditional blocks.
+func (p *Params) declares(name string) bool {
+	_, ok := p.field(name)
+	return ok
+}
+
+// bindUnknown applies p's unknown-key policy to the undeclared keys of raw,
+// copying them into out or recording them in errs.
+func (p *Params) bindUnknown(raw, out map[string]interface{}, errs *ValidationErrors) {
+	switch p.unknownKeys() {
+	case StripUnknown:
+	case RejectUnknown:
+		keys := make([]string, 0, len(raw))
+		for k := range raw {
+			if !p.accepts(k) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			errs.add(CodeUnknownField, k, pointer("", k), "unknown", raw[k], MsgUnknownField, k)
+		}
+	default:
+		for k, v := range raw {
+			if !p.accepts(k) {
+				out[k] = plainNumbers(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package grape provides tests for unknown.go functionality.
+//
+// Test Functions:
+// - TestUnknownKeysPassthroughByDefault: Tests that undeclared keys are kept by default
+// - TestUnknownKeysStrip: Tests dropping undeclared keys
+// - TestUnknownKeysReject: Tests unknown_field errors for undeclared keys
+// - TestUnknownKeysNested: Tests inheritance and overrides in nested schemas
+// - TestUnknownKeysToModel: Tests that stripped keys cannot reach ToModel
+package grape
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestUnknownKeysPassthroughByDefault(t *testing.T) {
+	schema := NewParams()
+	_ = schema.Optional("name").String()
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"name": "John", "role": "admin"}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if input["role"] != "admin" {
+		t.Errorf("Expected role to pass through, got %v", input["role"])
+	}
+}
+
+func TestUnknownKeysStrip(t *testing.T) {
+	schema := NewParams().UnknownKeys(StripUnknown)
+	_ = schema.Optional("name").String()
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"name": "John", "role": "admin"}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(input) != 1 || input.String("name") != "John" {
+		t.Errorf("Expected only name, got %v", input)
+	}
+}
+
+func TestUnknownKeysReject(t *testing.T) {
+	schema := NewParams().UnknownKeys(RejectUnknown)
+	_ = schema.Optional("name").String()
+
+	_, err := schema.BindAndValidate(createTestJSON(`{"name": "John", "role": "admin", "id": 1}`), "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", err)
+	}
+	if verrs[0].Code != CodeUnknownField || verrs[0].Field != "id" || verrs[0].Path != "/id" {
+		t.Errorf("Unexpected first error %+v", verrs[0])
+	}
+	if verrs[1].Message != "unknown field 'role'" || verrs[1].Value != "admin" {
+		t.Errorf("Unexpected second error %+v", verrs[1])
+	}
+
+	_, err = schema.BindQuery(url.Values{"name": {"John"}, "debug": {"1"}}, "")
+	if err == nil || err.Error() != "unknown field 'debug'" {
+		t.Errorf("Expected query key to be rejected, got %v", err)
+	}
+}
+
+func TestUnknownKeysNested(t *testing.T) {
+	address := NewParams()
+	_ = address.Optional("city").String()
+	item := NewParams().UnknownKeys(PassthroughUnknown)
+	_ = item.Optional("sku").String()
+
+	schema := NewParams().UnknownKeys(RejectUnknown)
+	_ = schema.Optional("address").JSON().WithSchema(address)
+	_ = schema.Optional("items").SliceOf(JSON, item)
+
+	raw := createTestJSON(`{"address": {"city": "Oslo", "zip": "0150"}, "items": [{"sku": "A", "note": "gift"}]}`)
+	_, err := schema.BindAndValidate(raw, "")
+	verrs, ok := err.(ValidationErrors)
+	if !ok || len(verrs) != 1 {
+		t.Fatalf("Expected 1 error, got %v", err)
+	}
+	if verrs[0].Path != "/address/zip" || verrs[0].Code != CodeUnknownField {
+		t.Errorf("Expected inherited rejection at /address/zip, got %+v", verrs[0])
+	}
+
+	raw = createTestJSON(`{"items": [{"sku": "A", "note": "gift"}]}`)
+	input, err := schema.BindAndValidate(raw, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	elem := input["items"].([]interface{})[0].(map[string]interface{})
+	if elem["note"] != "gift" {
+		t.Errorf("Expected nested passthrough override, got %v", elem)
+	}
+
+	// The nested schema keeps its own setting when bound on its own.
+	if address.unknown != InheritUnknown {
+		t.Error("Expected nested schema to stay unchanged")
+	}
+}
+
+func TestUnknownKeysToModel(t *testing.T) {
+	type account struct {
+		Name    string
+		IsAdmin bool
+	}
+	schema := NewParams().UnknownKeys(StripUnknown)
+	_ = schema.Optional("name").String()
+
+	input, err := schema.BindAndValidate(createTestJSON(`{"name": "John", "is_admin": true}`), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var acc account
+	input.ToModel(&acc)
+	if acc.IsAdmin {
+		t.Error("Expected is_admin to be stripped")
+	}
+}